@@ -16,16 +16,11 @@
 package antimeridian
 
 import (
-	"errors"
+	"context"
 
 	"github.com/twpayne/go-geom"
 )
 
-var (
-	ErrUnsupportedType   = errors.New("unsupported geometry type")
-	ErrUnsupportedLayout = errors.New("unsupported geometry layout")
-)
-
 // Cut divides a geometry at the antimeridian and the poles. A multi-geometry is
 // returned with the cut portions of the original geometry. If no cuts are
 // necessary Cut will return the original geometry with the winding normalized.
@@ -35,13 +30,88 @@ var (
 // cannot determine this to be so; for example, when the polygon extends over
 // both the north and south pole. For these instances, pass fixWinding = false
 func Cut(obj geom.T, fixWinding ...bool) (geom.T, error) {
-	switch geometry := obj.(type) {
-	case *geom.Polygon:
-		return cutPolygon(geometry, fixWinding...)
+	if len(fixWinding) > 0 {
+		return CutWithOptions(obj, WithFixWinding(fixWinding[0]))
+	}
+
+	return CutWithOptions(obj)
+}
+
+// CutWithOptions divides a geometry at the antimeridian and the poles in the
+// same way as Cut, with its behavior configured by opts.
+func CutWithOptions(obj geom.T, opts ...Option) (geom.T, error) {
+	return CutContext(context.Background(), obj, opts...)
+}
+
+// CutContext divides a geometry at the antimeridian and the poles in the same
+// way as CutWithOptions. Cancellation of ctx is checked between polygon
+// members, rings, segments and interior rings; once ctx is done the returned
+// error wraps ctx.Err() and describes how far cutting got.
+func CutContext(ctx context.Context, obj geom.T, opts ...Option) (geom.T, error) {
+	return newCutter(ctx, newOptions(opts...)).cut(obj)
+}
+
+// CutAt divides a geometry at the given meridian, instead of the antimeridian,
+// and the poles. The output longitudes are in [meridian-360, meridian].
+// Longitudes that are moved are rounded to 10 decimal places. Use Recenter to
+// bring the output into the range of a map with a different central meridian.
+func CutAt(obj geom.T, meridian float64, opts ...Option) (geom.T, error) {
+	c := newCutter(context.Background(), newOptions(opts...))
+	c.opts.meridian = meridian
+	c.opts.hasMeridian = true
+	return c.cut(obj)
+}
+
+// CutFlat divides a polygon given in go-geom's flat representation at the
+// antimeridian and the poles. flatCoords and ends are interpreted as they are
+// by geom.NewPolygonFlat. The result is returned in the flat representation of
+// a multi-polygon, suitable for geom.NewMultiPolygonFlat, with one entry in
+// endss for each polygon produced. The result does not share memory with
+// flatCoords and ends unless WithInPlace is set, in which case a polygon that
+// does not need cutting is returned in flatCoords and ends themselves.
+func CutFlat(layout geom.Layout, flatCoords []float64, ends []int, opts ...Option) ([]float64, [][]int, error) {
+	c := newCutter(context.Background(), newOptions(opts...))
+	return c.cutFlat(layout, flatCoords, ends)
+}
+
+// CutPolygon divides a polygon at the antimeridian and the poles in the same
+// way as CutWithOptions, but always returns a multi-polygon, which has a
+// single member if no cuts were necessary. The result does not share its
+// coordinates with poly unless WithInPlace is set.
+func CutPolygon(poly *geom.Polygon, opts ...Option) (*geom.MultiPolygon, error) {
+	if poly == nil {
+		return nil, ErrUnsupportedType
+	}
+
+	c := newCutter(context.Background(), newOptions(opts...))
+	result, err := c.cut(poly)
+	if err != nil {
+		return nil, err
+	}
+
+	switch geometry := result.(type) {
 	case *geom.MultiPolygon:
-		return cutMultiPolygon(geometry, fixWinding...)
+		return geometry, nil
+	case *geom.Polygon:
+		if geometry == poly && !c.opts.inPlace {
+			geometry = geometry.Clone()
+		}
+
+		return geom.NewMultiPolygonFlat(geometry.Layout(), geometry.FlatCoords(), [][]int{geometry.Ends()}), nil
 	default:
-		// unsupported type
-		return obj, ErrUnsupportedType
+		return nil, ErrUnsupportedType
+	}
+}
+
+// CutMultiPolygon divides a multi-polygon at the antimeridian and the poles in
+// the same way as CutWithOptions. With WithPartialResults the members that
+// were cut are returned along with the error.
+func CutMultiPolygon(multiPoly *geom.MultiPolygon, opts ...Option) (*geom.MultiPolygon, error) {
+	if multiPoly == nil {
+		return nil, ErrUnsupportedType
 	}
+
+	result, err := CutWithOptions(multiPoly, opts...)
+	cut, _ := result.(*geom.MultiPolygon)
+	return cut, err
 }
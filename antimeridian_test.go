@@ -0,0 +1,75 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+func readInput(name string) geom.T {
+	inp, err := os.ReadFile(fmt.Sprintf("test_data/input/%s.json", name))
+	Expect(err).To(BeNil())
+
+	var inGeom geom.T
+	err = geojson.Unmarshal(inp, &inGeom)
+	Expect(err).To(BeNil())
+
+	return inGeom
+}
+
+var _ = Describe("Cutting with a context", func() {
+	It("cuts like Cut when the context is not cancelled", func() {
+		obj := readInput("complex-split")
+
+		expected, err := antimeridian.Cut(obj)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutContext(context.Background(), obj)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal(expected.FlatCoords()))
+	})
+
+	It("returns the context error with the progress made", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := antimeridian.CutContext(ctx, readInput("multi-split"))
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(err.Error()).To(ContainSubstring("cancelled after 0 of 1 rings"))
+
+		var cutErr *antimeridian.Error
+		Expect(errors.As(err, &cutErr)).To(BeTrue())
+		Expect(cutErr.Polygon).To(Equal(0))
+		Expect(cutErr.Ring).To(Equal(0))
+	})
+
+	It("stops cutting members in parallel", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := antimeridian.CutContext(ctx, readInput("multi-split"), antimeridian.WithMemberWorkers(2))
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
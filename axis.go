@@ -0,0 +1,76 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+// AxisOrder is the order of the first two ordinates of each coordinate
+type AxisOrder int
+
+const (
+	// LonLat puts longitude first, as GeoJSON does. It is the default.
+	LonLat AxisOrder = iota
+	// LatLon puts latitude first, as ISO 6709 and some WMS 1.3 sources do
+	LatLon
+	// AutoAxisOrder detects the axis order of each geometry with
+	// DetectAxisOrder, falling back to LonLat when it can't tell
+	AutoAxisOrder
+)
+
+// DetectAxisOrder guesses the axis order of a geometry from the range of its
+// ordinates. A geometry with a first ordinate outside of [-90, 90] must be
+// LonLat and one with a second ordinate outside of [-90, 90] must be LatLon.
+// certain is false if neither or both are the case, in which case LonLat is
+// returned.
+func DetectAxisOrder(obj geom.T) (order AxisOrder, certain bool) {
+	return detectAxisOrder(obj.FlatCoords(), obj.Stride())
+}
+
+func detectAxisOrder(flatCoords []float64, stride int) (AxisOrder, bool) {
+	lonFirst, latFirst := false, false
+	for idx := 0; idx+1 < len(flatCoords); idx += stride {
+		lonFirst = lonFirst || math.Abs(flatCoords[idx]) > 90
+		latFirst = latFirst || math.Abs(flatCoords[idx+1]) > 90
+	}
+
+	if latFirst && !lonFirst {
+		return LatLon, true
+	}
+
+	return LonLat, lonFirst && !latFirst
+}
+
+// resolve returns the axis order of flatCoords, detecting it if order is
+// AutoAxisOrder
+func (order AxisOrder) resolve(flatCoords []float64, stride int) AxisOrder {
+	if order == AutoAxisOrder {
+		order, _ = detectAxisOrder(flatCoords, stride)
+	}
+
+	return order
+}
+
+// swapAxes swaps the first two ordinates of every coordinate in flatCoords in
+// place
+func swapAxes(flatCoords []float64, stride int) {
+	for idx := 0; idx+1 < len(flatCoords); idx += stride {
+		flatCoords[idx], flatCoords[idx+1] = flatCoords[idx+1], flatCoords[idx]
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+// swapped returns a copy of a polygon with its latitudes first
+func swapped(polygon *geom.Polygon) *geom.Polygon {
+	flatCoords := make([]float64, 0, len(polygon.FlatCoords()))
+	for idx := 0; idx < len(polygon.FlatCoords()); idx += polygon.Stride() {
+		coord := polygon.FlatCoords()[idx : idx+polygon.Stride()]
+		flatCoords = append(flatCoords, coord[1], coord[0])
+		flatCoords = append(flatCoords, coord[2:]...)
+	}
+
+	return geom.NewPolygonFlat(polygon.Layout(), flatCoords, polygon.Ends())
+}
+
+var _ = Describe("Axis order", func() {
+	It("detects the axis order from the range of the ordinates", func() {
+		polygon := readInput("split").(*geom.Polygon)
+
+		order, certain := antimeridian.DetectAxisOrder(polygon)
+		Expect(order).To(Equal(antimeridian.LonLat))
+		Expect(certain).To(BeTrue())
+
+		order, certain = antimeridian.DetectAxisOrder(swapped(polygon))
+		Expect(order).To(Equal(antimeridian.LatLon))
+		Expect(certain).To(BeTrue())
+
+		small := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {10, 0}, {10, 10}, {0, 0}},
+		})
+		order, certain = antimeridian.DetectAxisOrder(small)
+		Expect(order).To(Equal(antimeridian.LonLat))
+		Expect(certain).To(BeFalse())
+	})
+
+	DescribeTable("cutting latitude first input",
+		func(order antimeridian.AxisOrder) {
+			polygon := readInput("split").(*geom.Polygon)
+			expected, err := antimeridian.Cut(polygon)
+			Expect(err).To(BeNil())
+
+			input := swapped(polygon)
+			result, err := antimeridian.CutWithOptions(input, antimeridian.WithAxisOrder(order))
+			Expect(err).To(BeNil())
+			Expect(result.FlatCoords()).To(Equal(expected.FlatCoords()))
+
+			// the input is left untouched
+			Expect(input.FlatCoords()).To(Equal(swapped(polygon).FlatCoords()))
+		},
+		Entry("explicitly", antimeridian.LatLon),
+		Entry("automatically", antimeridian.AutoAxisOrder),
+	)
+
+	It("swaps the output back when asked", func() {
+		polygon := readInput("split").(*geom.Polygon)
+		expected, err := antimeridian.Cut(polygon)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutWithOptions(swapped(polygon),
+			antimeridian.WithAxisOrder(antimeridian.LatLon),
+			antimeridian.WithRestoreAxisOrder(true),
+		)
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		for idx := range multiPolygon.NumPolygons() {
+			Expect(multiPolygon.Polygon(idx).FlatCoords()).To(Equal(
+				swapped(expected.(*geom.MultiPolygon).Polygon(idx)).FlatCoords(),
+			))
+		}
+	})
+
+	It("swaps flat coordinates", func() {
+		polygon := readInput("split").(*geom.Polygon)
+		expected, expectedEndss, err := antimeridian.CutFlat(polygon.Layout(), polygon.FlatCoords(), polygon.Ends())
+		Expect(err).To(BeNil())
+
+		input := swapped(polygon)
+		flatCoords, endss, err := antimeridian.CutFlat(input.Layout(), input.FlatCoords(), input.Ends(),
+			antimeridian.WithAxisOrder(antimeridian.LatLon),
+		)
+		Expect(err).To(BeNil())
+		Expect(flatCoords).To(Equal(expected))
+		Expect(endss).To(Equal(expectedEndss))
+	})
+
+	It("tests points given latitude first", func() {
+		ring := geom.NewLinearRing(geom.XY).MustSetCoords([]geom.Coord{
+			{0, 100}, {10, 100}, {10, 120}, {0, 120}, {0, 100},
+		})
+
+		Expect(antimeridian.ContainsPoint(geom.Coord{5, 110}, ring, antimeridian.WithAxisOrder(antimeridian.LatLon))).To(BeTrue())
+		Expect(antimeridian.ContainsPoint(geom.Coord{5, 130}, ring, antimeridian.WithAxisOrder(antimeridian.AutoAxisOrder))).To(BeFalse())
+	})
+})
@@ -0,0 +1,104 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/twpayne/go-geom"
+)
+
+// CutAll cuts every geometry in objs using up to workers goroutines and
+// returns the results in the same order as objs. If workers is less than one
+// GOMAXPROCS workers are used.
+//
+// Geometries that fail to be cut have a nil result, or their partial result
+// when WithPartialResults is used, and the returned error joins the errors for
+// each of them. If ctx is cancelled no further geometries are started; the
+// results produced so far are returned and the error joins ctx.Err() with the
+// errors of the geometries that were cut.
+func CutAll(ctx context.Context, objs []geom.T, workers int, opts ...Option) ([]geom.T, error) {
+	o := newOptions(opts...)
+	results := make([]geom.T, len(objs))
+	errs := make([]error, len(objs))
+
+	cutters := make([]*cutter, resolveWorkers(workers, len(objs)))
+	for idx := range cutters {
+		cutters[idx] = newCutter(ctx, o)
+	}
+
+	err := forEach(ctx, len(objs), len(cutters), func(worker, idx int) {
+		result, err := cutters[worker].cut(objs[idx])
+		if err != nil {
+			errs[idx] = fmt.Errorf("geometry %d: %w", idx, err)
+			if !o.partial || errors.Is(err, ErrUnsupportedType) {
+				return
+			}
+		}
+
+		results[idx] = result
+	})
+
+	return results, errors.Join(append([]error{err}, errs...)...)
+}
+
+// resolveWorkers returns the number of workers to use for n items of work
+func resolveWorkers(workers, n int) int {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return max(1, min(workers, n))
+}
+
+// forEach calls fn for every index in [0, n) using the given number of
+// goroutines. fn is passed the number of the worker calling it, which is
+// in [0, workers). No new indexes are handed out once ctx is done, in which
+// case ctx.Err() is returned.
+func forEach(ctx context.Context, n, workers int, fn func(worker, idx int)) error {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				fn(worker, idx)
+			}
+		}()
+	}
+
+	var err error
+dispatch:
+	for idx := range n {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		case indexes <- idx:
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return err
+}
@@ -0,0 +1,132 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+// cancellingPoint cancels a context when cutting reads its coordinates
+type cancellingPoint struct {
+	*geom.Point
+	cancel context.CancelFunc
+}
+
+func (p cancellingPoint) FlatCoords() []float64 {
+	p.cancel()
+	return p.Point.FlatCoords()
+}
+
+var _ = Describe("Cutting in batches", func() {
+	names := []string{"split", "north-pole", "one-hole", "multi-split", "complex-split", "simple", "two-holes"}
+
+	It("returns results in input order", func() {
+		objs := make([]geom.T, len(names))
+		for idx, name := range names {
+			objs[idx] = readInput(name)
+		}
+
+		results, err := antimeridian.CutAll(context.Background(), objs, 3)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(len(objs)))
+
+		for idx, obj := range objs {
+			expected, err := antimeridian.Cut(obj)
+			Expect(err).To(BeNil())
+			Expect(results[idx].FlatCoords()).To(Equal(expected.FlatCoords()), names[idx])
+		}
+	})
+
+	It("reports which geometries failed", func() {
+		objs := []geom.T{
+			readInput("split"),
+			geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}),
+			readInput("simple"),
+		}
+
+		results, err := antimeridian.CutAll(context.Background(), objs, 2)
+		Expect(errors.Is(err, antimeridian.ErrUnsupportedType)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("geometry 1"))
+		Expect(results[0]).NotTo(BeNil())
+		Expect(results[1]).To(BeNil())
+		Expect(results[2]).NotTo(BeNil())
+	})
+
+	It("stops when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := antimeridian.CutAll(ctx, []geom.T{readInput("split")}, 1)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	It("locates failures in polygons cut after a multi-polygon", func() {
+		multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}},
+			{{{20, 0}, {30, 0}, {30, 10}, {20, 0}}},
+			{{{40, 0}, {50, 0}, {50, 10}, {40, 0}}},
+		})
+		invalid := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {10, 0}, {10, 100}, {0, 0}},
+		})
+
+		_, err := antimeridian.CutAll(context.Background(), []geom.T{multiPolygon, invalid}, 1)
+		Expect(err).To(MatchError(antimeridian.ErrLatitudeOutOfRange))
+		Expect(err.Error()).To(ContainSubstring("geometry 1: polygon 0"))
+
+		var cutErr *antimeridian.Error
+		Expect(errors.As(err, &cutErr)).To(BeTrue())
+		Expect(cutErr.Polygon).To(Equal(0))
+	})
+
+	It("keeps the results and errors from before the context was cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		objs := []geom.T{
+			readInput("split"),
+			cancellingPoint{Point: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}), cancel: cancel},
+			readInput("split"),
+			readInput("split"),
+		}
+
+		results, err := antimeridian.CutAll(ctx, objs, 1)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(err).To(MatchError(antimeridian.ErrUnsupportedType))
+		Expect(err.Error()).To(ContainSubstring("geometry 1"))
+		Expect(results).To(HaveLen(len(objs)))
+		Expect(results[0]).NotTo(BeNil())
+		Expect(results[1:]).To(HaveEach(BeNil()))
+	})
+
+	It("cuts multi-polygon members in parallel", func() {
+		obj := readInput("multi-split")
+
+		expected, err := antimeridian.Cut(obj)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutWithOptions(obj, antimeridian.WithMemberWorkers(4))
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal(expected.FlatCoords()))
+		Expect(result.Endss()).To(Equal(expected.Endss()))
+	})
+})
@@ -0,0 +1,166 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-geospatial/antimeridian"
+	"github.com/twpayne/go-geom"
+)
+
+// wrap keeps a longitude in [-180, 180)
+func wrap(lon float64) float64 {
+	if lon >= 180 {
+		return lon - 360
+	}
+
+	return lon
+}
+
+// syntheticPolygon builds a rectangle spanning 170 to 190 degrees of
+// longitude with edgeVertices vertices along each side and a grid of small
+// square holes, none of which touch the antimeridian.
+func syntheticPolygon(edgeVertices, holesPerSide int) *geom.Polygon {
+	const (
+		west, east   = 170.0, 190.0
+		south, north = -40.0, 40.0
+	)
+
+	exterior := make([]geom.Coord, 0, 4*edgeVertices+1)
+	for idx := range edgeVertices {
+		exterior = append(exterior, geom.Coord{wrap(west + (east-west)*float64(idx)/float64(edgeVertices)), south})
+	}
+	for idx := range edgeVertices {
+		exterior = append(exterior, geom.Coord{wrap(east), south + (north-south)*float64(idx)/float64(edgeVertices)})
+	}
+	for idx := range edgeVertices {
+		exterior = append(exterior, geom.Coord{wrap(east - (east-west)*float64(idx)/float64(edgeVertices)), north})
+	}
+	for idx := range edgeVertices {
+		exterior = append(exterior, geom.Coord{west, north - (north-south)*float64(idx)/float64(edgeVertices)})
+	}
+	exterior = append(exterior, exterior[0])
+
+	rings := [][]geom.Coord{exterior}
+
+	// holes are laid out in a grid with a half cell gap on either side of the
+	// antimeridian
+	cellWidth := (east - west) / float64(2*holesPerSide+1)
+	cellHeight := (north - south) / float64(holesPerSide)
+	for col := range 2*holesPerSide + 1 {
+		if col == holesPerSide {
+			continue
+		}
+
+		for row := range holesPerSide {
+			minX := west + float64(col)*cellWidth + cellWidth/4
+			maxX := minX + cellWidth/2
+			minY := south + float64(row)*cellHeight + cellHeight/4
+			maxY := minY + cellHeight/2
+			rings = append(rings, []geom.Coord{
+				{wrap(minX), minY}, {wrap(minX), maxY}, {wrap(maxX), maxY}, {wrap(maxX), minY}, {wrap(minX), minY},
+			})
+		}
+	}
+
+	return geom.NewPolygon(geom.XY).MustSetCoords(rings)
+}
+
+func BenchmarkCutHoles(b *testing.B) {
+	for _, holesPerSide := range []int{10, 30, 70} {
+		polygon := syntheticPolygon(1000, holesPerSide)
+		b.Run(fmt.Sprintf("holes=%d", polygon.NumLinearRings()-1), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := antimeridian.Cut(polygon); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCutVertices(b *testing.B) {
+	for _, edgeVertices := range []int{100, 1000, 10000} {
+		polygon := syntheticPolygon(edgeVertices, 10)
+		b.Run(fmt.Sprintf("vertices=%d", polygon.NumCoords()), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := antimeridian.Cut(polygon); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCutFlat(b *testing.B) {
+	polygon := syntheticPolygon(10000, 10)
+	b.Run(fmt.Sprintf("vertices=%d", polygon.NumCoords()), func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, _, err := antimeridian.CutFlat(polygon.Layout(), polygon.FlatCoords(), polygon.Ends()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCutNoCrossing(b *testing.B) {
+	// a clockwise polygon that does not cross the antimeridian
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{100, 40}, {90, 40}, {90, 50}, {100, 50}, {100, 40}},
+	})
+
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := antimeridian.CutWithOptions(polygon); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("in-place", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := antimeridian.CutWithOptions(polygon, antimeridian.WithInPlace(true)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCutAll(b *testing.B) {
+	objs := make([]geom.T, 1000)
+	for idx := range objs {
+		objs[idx] = syntheticPolygon(100, 5)
+	}
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := antimeridian.CutAll(context.Background(), objs, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import "slices"
+
+// comparePolygons orders polygons from west to east and then from south to
+// north by the bounding boxes of their exterior rings
+func comparePolygons(a, b [][]float64, stride int) int {
+	boxA, boxB := newBBox(a[0], stride), newBBox(b[0], stride)
+	for _, pair := range [][2]float64{
+		{boxA.MinX, boxB.MinX},
+		{boxA.MinY, boxB.MinY},
+		{boxA.MaxX, boxB.MaxX},
+		{boxA.MaxY, boxB.MaxY},
+	} {
+		if order := cmpFloat(pair[0], pair[1]); order != 0 {
+			return order
+		}
+	}
+
+	return 0
+}
+
+// sortPolygons sorts polygons with comparePolygons if the options ask for it.
+// reordered reports if the order of the polygons changed.
+func (c *cutter) sortPolygons(polygons [][][]float64, stride int) (reordered bool) {
+	if !c.opts.sortPieces {
+		return false
+	}
+
+	compare := func(a, b [][]float64) int {
+		return comparePolygons(a, b, stride)
+	}
+	if slices.IsSortedFunc(polygons, compare) {
+		return false
+	}
+
+	slices.SortStableFunc(polygons, compare)
+	return true
+}
+
+// rotateRings rotates every ring of polygons to start at its lowest vertex,
+// the one with the smallest longitude and then the smallest latitude, if the
+// options ask for it. Rings are copied before they are rotated unless the
+// cutter is allowed to modify its input. rotated reports if any ring changed.
+func (c *cutter) rotateRings(polygons [][][]float64, stride int) (rotated bool) {
+	if !c.opts.canonicalStart {
+		return false
+	}
+
+	for _, polygon := range polygons {
+		for idx, ring := range polygon {
+			start := lowestVertex(ring, stride)
+			if start == 0 {
+				continue
+			}
+
+			// the closing coordinate is dropped and added back after the
+			// coordinate that is the new start
+			rotatedRing := make([]float64, 0, len(ring))
+			if c.opts.inPlace {
+				rotatedRing = c.scratch[:0]
+			}
+
+			open := ring[:len(ring)-stride]
+			rotatedRing = append(rotatedRing, open[start:]...)
+			rotatedRing = append(rotatedRing, open[:start]...)
+			rotatedRing = append(rotatedRing, open[start:start+stride]...)
+
+			if c.opts.inPlace {
+				c.scratch = rotatedRing
+				rotatedRing = ring
+				copy(rotatedRing, c.scratch)
+			}
+
+			polygon[idx] = rotatedRing
+			rotated = true
+		}
+	}
+
+	return rotated
+}
+
+// lowestVertex returns the offset of the vertex of ring with the smallest
+// longitude and then the smallest latitude, ignoring the closing coordinate
+func lowestVertex(ring []float64, stride int) int {
+	lowest := 0
+	for idx := stride; idx < len(ring)-stride; idx += stride {
+		if ring[idx] < ring[lowest] || (ring[idx] == ring[lowest] && ring[idx+1] < ring[lowest+1]) {
+			lowest = idx
+		}
+	}
+
+	return lowest
+}
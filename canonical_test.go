@@ -0,0 +1,143 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+// rotated returns a copy of polygon with its exterior ring starting shift
+// vertices later
+func rotated(polygon *geom.Polygon, shift int) *geom.Polygon {
+	coords := polygon.Coords()
+	open := coords[0][:len(coords[0])-1]
+	ring := append(append([]geom.Coord{}, open[shift:]...), open[:shift]...)
+	coords[0] = append(ring, ring[0])
+
+	return geom.NewPolygon(polygon.Layout()).MustSetCoords(coords)
+}
+
+var _ = Describe("Typed entry points", func() {
+	It("always returns a multi-polygon for a polygon", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}},
+		})
+
+		result, err := antimeridian.CutPolygon(polygon)
+		Expect(err).To(BeNil())
+		Expect(result.NumPolygons()).To(Equal(1))
+		Expect(result.FlatCoords()).To(Equal(polygon.FlatCoords()))
+
+		result.FlatCoords()[0] = 15
+		Expect(polygon.FlatCoords()[0]).To(Equal(10.0))
+	})
+
+	It("returns the pieces of a cut polygon", func() {
+		result, err := antimeridian.CutPolygon(readInput("split").(*geom.Polygon))
+		Expect(err).To(BeNil())
+		Expect(result.NumPolygons()).To(Equal(2))
+	})
+
+	It("cuts multi-polygons", func() {
+		expected, err := antimeridian.Cut(readInput("multi-split"))
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutMultiPolygon(readInput("multi-split").(*geom.MultiPolygon))
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+	})
+
+	It("rejects nil geometries", func() {
+		_, err := antimeridian.CutPolygon(nil)
+		Expect(err).To(MatchError(antimeridian.ErrUnsupportedType))
+
+		_, err = antimeridian.CutMultiPolygon(nil)
+		Expect(err).To(MatchError(antimeridian.ErrUnsupportedType))
+	})
+})
+
+var _ = Describe("Deterministic output", func() {
+	canonical := []antimeridian.Option{antimeridian.WithSortedPieces(), antimeridian.WithCanonicalStart()}
+
+	DescribeTable("gives the same output whatever the start of the input",
+		func(name string) {
+			polygon := readInput(name).(*geom.Polygon)
+
+			expected, err := antimeridian.CutPolygon(polygon, canonical...)
+			Expect(err).To(BeNil())
+
+			for shift := 1; shift < polygon.LinearRing(0).NumCoords()-1; shift++ {
+				result, err := antimeridian.CutPolygon(rotated(polygon, shift), canonical...)
+				Expect(err).To(BeNil())
+				Expect(result.FlatCoords()).To(Equal(expected.FlatCoords()))
+				Expect(result.Endss()).To(Equal(expected.Endss()))
+			}
+		},
+		Entry("split", "split"),
+		Entry("complex split", "complex-split"),
+		Entry("north pole", "north-pole"),
+		Entry("one hole", "one-hole"),
+	)
+
+	It("sorts pieces from west to east and south to north", func() {
+		result, err := antimeridian.CutWithOptions(readInput("complex-split"), antimeridian.WithSortedPieces())
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(BeNumerically(">", 2))
+		for idx := 1; idx < multiPolygon.NumPolygons(); idx++ {
+			prev, next := multiPolygon.Polygon(idx-1).Bounds(), multiPolygon.Polygon(idx).Bounds()
+			Expect(prev.Min(0) < next.Min(0) || (prev.Min(0) == next.Min(0) && prev.Min(1) <= next.Min(1))).To(BeTrue())
+		}
+	})
+
+	It("starts rings at their lowest vertex", func() {
+		result, err := antimeridian.CutWithOptions(readInput("complex-split"), antimeridian.WithCanonicalStart())
+		Expect(err).To(BeNil())
+		Expect(antimeridian.Verify(result)).To(BeEmpty())
+
+		for _, polygon := range polygonsOf(result) {
+			for idx := range polygon.NumLinearRings() {
+				coords := polygon.LinearRing(idx).Coords()
+				for _, coord := range coords {
+					Expect(coords[0].X() < coord.X() || (coords[0].X() == coord.X() && coords[0].Y() <= coord.Y())).To(BeTrue())
+				}
+			}
+		}
+	})
+
+	It("rotates polygons that are not cut", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{20, 0}, {20, 10}, {10, 10}, {10, 0}, {20, 0}},
+		})
+		original := polygon.Clone()
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithCanonicalStart())
+		Expect(err).To(BeNil())
+		Expect(polygon).To(Equal(original))
+		Expect(result.(*geom.Polygon).Coords()).To(Equal([][]geom.Coord{
+			{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}},
+		}))
+
+		result, err = antimeridian.CutWithOptions(polygon, antimeridian.WithCanonicalStart(), antimeridian.WithInPlace(true))
+		Expect(err).To(BeNil())
+		Expect(result).To(BeIdenticalTo(polygon))
+		Expect(polygon.FlatCoords()[:2]).To(Equal([]float64{10, 0}))
+	})
+})
@@ -0,0 +1,252 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+)
+
+// MercatorLatitudeLimit is the latitude, in degrees, at which Web Mercator
+// ends. It can be passed to WithLatitudeLimit.
+const MercatorLatitudeLimit = 85.05112878
+
+// clipLatitudes intersects polygons with the band between the latitude limit
+// of the options and its negative. changed reports if any polygon was clipped.
+func (c *cutter) clipLatitudes(polygons [][][]float64, stride int) (clipped [][][]float64, changed bool) {
+	limit := c.opts.latitudeLimit
+	if c.opts.coordinateSystem == WebMercator {
+		// the working frame is linear in Mercator y rather than latitude
+		limit = mercatorY(limit) / c.frame.scaleY
+	}
+
+	clipped = make([][][]float64, 0, len(polygons))
+	for _, polygon := range polygons {
+		north, northChanged := clipAbove(polygon, stride, limit, c.opts.densifyStep)
+		changed = changed || northChanged
+
+		for _, piece := range north {
+			// the southern limit is clipped by turning the polygon upside
+			// down, which keeps its winding
+			rotate(piece, stride)
+			south, southChanged := clipAbove(piece, stride, limit, c.opts.densifyStep)
+			changed = changed || southChanged
+
+			for _, piece := range south {
+				rotate(piece, stride)
+			}
+
+			clipped = append(clipped, south...)
+		}
+	}
+
+	return clipped, changed
+}
+
+// mercatorY returns the Web Mercator y, in metres, of a latitude in degrees
+func mercatorY(lat float64) float64 {
+	return 6378137 * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+}
+
+// mercatorLat returns the latitude, in degrees, of a Web Mercator y
+func mercatorLat(y float64) float64 {
+	return 360*math.Atan(math.Exp(y/6378137))/math.Pi - 90
+}
+
+// rotate turns every ring of a polygon by 180 degrees in place
+func rotate(polygon [][]float64, stride int) {
+	for _, ring := range polygon {
+		for idx := 0; idx < len(ring); idx += stride {
+			ring[idx], ring[idx+1] = -ring[idx], -ring[idx+1]
+		}
+	}
+}
+
+// chain is a stretch of a ring that lies below the clipping line. It enters
+// below the line at its first coordinate and leaves at its last, both of which
+// are on the line.
+type chain struct {
+	coords []float64
+	// next is the index of the chain that follows this one
+	next int
+}
+
+// clipAbove removes the part of a counter-clockwise polygon above the line
+// y = limit, returning the polygons that are left. The rings are copied so
+// that the result can be modified. The stretches of the rings below the line
+// are joined up along the line: from the point where the boundary leaves, it
+// follows the line west to the nearest point where the boundary comes back.
+// The edges added along the line are densified to step degrees, and to no more
+// than 180 degrees so that they do not read as crossing the antimeridian.
+func clipAbove(polygon [][]float64, stride int, limit, step float64) ([][][]float64, bool) {
+	below := func(coord []float64) bool {
+		return coord[1] <= limit
+	}
+
+	if step <= 0 || step > 180 {
+		step = 180
+	}
+
+	whole := make([][]float64, 0, len(polygon))
+	chains := make([]chain, 0)
+	changed := false
+	for ringIdx, ring := range polygon {
+		start := -1
+		for idx := range len(ring) / stride {
+			if below(coordAt(ring, idx, stride)) {
+				start = idx
+				break
+			}
+		}
+
+		if start < 0 {
+			// the whole ring is above the line
+			changed = true
+			if ringIdx == 0 {
+				return nil, true
+			}
+
+			continue
+		}
+
+		ringChains := clipRing(ring, stride, start, limit)
+		if ringChains == nil {
+			whole = append(whole, slices.Clone(ring))
+			continue
+		}
+
+		changed = true
+		for _, coords := range ringChains {
+			chains = append(chains, chain{coords: coords, next: -1})
+		}
+	}
+
+	if len(chains) == 0 {
+		return [][][]float64{whole}, changed
+	}
+
+	// link each chain to the chain entering nearest to the west of where it
+	// leaves
+	for idx := range chains {
+		exit := lastCoord(chains[idx].coords, stride)[0]
+		best := -1
+		for candidate := range chains {
+			entry := chains[candidate].coords[0]
+			if entry > exit {
+				continue
+			}
+
+			if best < 0 || entry > chains[best].coords[0] {
+				best = candidate
+			}
+		}
+
+		chains[idx].next = best
+	}
+
+	// follow the links to build the new exteriors
+	polygons := make([][][]float64, 0)
+	used := make([]bool, len(chains))
+	for first := range chains {
+		if used[first] {
+			continue
+		}
+
+		ring := make([]float64, 0)
+		for idx := first; idx >= 0 && !used[idx]; idx = chains[idx].next {
+			used[idx] = true
+			if len(ring) > 0 {
+				ring = appendDensified(ring, lastCoord(ring, stride), chains[idx].coords, step)
+			}
+			ring = appendDistinct(ring, chains[idx].coords, stride)
+		}
+
+		ring = appendDensified(ring, lastCoord(ring, stride), coordAt(ring, 0, stride), step)
+		ring = appendDistinct(ring, coordAt(ring, 0, stride), stride)
+		if len(ring) >= 4*stride {
+			polygons = append(polygons, [][]float64{ring})
+		}
+	}
+
+	// the exterior was clipped, so the rings that were not are holes, which go
+	// with the polygon that contains them
+	indexes := make([]*edgeIndex, len(polygons))
+	for idx, polygon := range polygons {
+		indexes[idx] = newRingIndex(polygon[0], stride)
+	}
+
+	for _, hole := range whole {
+		pt := representativePoint(hole, stride)
+		for idx := range polygons {
+			if indexes[idx].containsPoint(pt) {
+				polygons[idx] = append(polygons[idx], hole)
+				break
+			}
+		}
+	}
+
+	return polygons, changed
+}
+
+// clipRing splits a closed ring into the chains that lie below the line
+// y = limit, starting from the coordinate at start, which must be below the
+// line. nil is returned if the whole ring is below the line.
+func clipRing(ring []float64, stride, start int, limit float64) [][]float64 {
+	numCoords := len(ring)/stride - 1
+	chains := make([][]float64, 0)
+	current := append([]float64(nil), coordAt(ring, start, stride)...)
+	for step := 1; step <= numCoords; step++ {
+		prev := coordAt(ring, (start+step-1)%numCoords, stride)
+		coord := coordAt(ring, (start+step)%numCoords, stride)
+
+		switch prevBelow, coordBelow := prev[1] <= limit, coord[1] <= limit; {
+		case prevBelow && coordBelow:
+			current = append(current, coord...)
+		case prevBelow:
+			// leaving
+			exit := interpolateCoord(prev, coord, (limit-prev[1])/(coord[1]-prev[1]))
+			exit[1] = limit
+			chains = append(chains, appendDistinct(current, exit, stride))
+			current = nil
+		case coordBelow:
+			// entering
+			entry := interpolateCoord(prev, coord, (limit-prev[1])/(coord[1]-prev[1]))
+			entry[1] = limit
+			current = appendDistinct(entry, coord, stride)
+		}
+	}
+
+	if len(chains) == 0 {
+		return nil
+	}
+
+	// the chain that was open at the start carries on into the first chain
+	if len(current) > 0 {
+		chains[0] = appendDistinct(current, chains[0][stride:], stride)
+	}
+
+	return chains
+}
+
+// appendDistinct appends coords to dst, skipping the first coordinate of coords
+// if it has the same x and y as the last coordinate of dst
+func appendDistinct(dst, coords []float64, stride int) []float64 {
+	if len(dst) > 0 && len(coords) > 0 && sameXY(lastCoord(dst, stride), coords) {
+		coords = coords[stride:]
+	}
+
+	return append(dst, coords...)
+}
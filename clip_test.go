@@ -0,0 +1,148 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+// polygonArea returns the area of a polygon, taking away its holes
+func polygonArea(polygon *geom.Polygon) float64 {
+	area := 0.0
+	for idx := range polygon.NumLinearRings() {
+		coords := polygon.LinearRing(idx).Coords()
+		for ii := 1; ii < len(coords); ii++ {
+			area += (coords[ii-1].X()*coords[ii].Y() - coords[ii].X()*coords[ii-1].Y()) / 2
+		}
+	}
+
+	return area
+}
+
+// polygonsOf returns the polygons of a polygon or multi-polygon
+func polygonsOf(obj geom.T) []*geom.Polygon {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		return []*geom.Polygon{geometry}
+	case *geom.MultiPolygon:
+		polygons := make([]*geom.Polygon, geometry.NumPolygons())
+		for idx := range polygons {
+			polygons[idx] = geometry.Polygon(idx)
+		}
+		return polygons
+	default:
+		return nil
+	}
+}
+
+var _ = Describe("Clipping to a latitude band", func() {
+	It("keeps pole pieces within the limit", func() {
+		for _, name := range []string{"north-pole", "south-pole", "both-poles", "cw-only"} {
+			result, err := antimeridian.CutWithOptions(readInput(name),
+				antimeridian.WithFixWinding(name != "both-poles" && name != "cw-only"),
+				antimeridian.WithLatitudeLimit(antimeridian.MercatorLatitudeLimit),
+			)
+			Expect(err).To(BeNil())
+			Expect(antimeridian.Verify(result)).To(BeEmpty(), name)
+
+			for idx := 1; idx < len(result.FlatCoords()); idx += result.Stride() {
+				Expect(math.Abs(result.FlatCoords()[idx])).To(BeNumerically("<=", antimeridian.MercatorLatitudeLimit), name)
+			}
+			Expect(result.FlatCoords()).To(ContainElement(Or(
+				BeNumerically("~", antimeridian.MercatorLatitudeLimit),
+				BeNumerically("~", -antimeridian.MercatorLatitudeLimit),
+			)), name)
+		}
+	})
+
+	It("clips the fixtures to valid geometries", func() {
+		names, err := filepath.Glob("test_data/input/*.json")
+		Expect(err).To(BeNil())
+		Expect(names).NotTo(BeEmpty())
+
+		for _, name := range names {
+			name = strings.TrimSuffix(filepath.Base(name), ".json")
+			result, err := antimeridian.CutWithOptions(readInput(name),
+				antimeridian.WithLatitudeLimit(antimeridian.MercatorLatitudeLimit),
+			)
+			Expect(err).To(BeNil(), name)
+			Expect(antimeridian.Verify(result)).To(BeEmpty(), name)
+		}
+	})
+
+	It("leaves polygons within the band alone", func() {
+		obj := readInput("split")
+
+		expected, err := antimeridian.Cut(obj)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutWithOptions(obj, antimeridian.WithLatitudeLimit(antimeridian.MercatorLatitudeLimit))
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+	})
+
+	It("splits polygons that leave the band more than once", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {10, 0}, {10, 15}, {20, 15}, {20, 0}, {30, 0}, {30, 20}, {0, 20}, {0, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithLatitudeLimit(10))
+		Expect(err).To(BeNil())
+
+		polygons := polygonsOf(result)
+		Expect(polygons).To(HaveLen(2))
+		for _, polygon := range polygons {
+			Expect(polygonArea(polygon)).To(BeNumerically("~", 100))
+		}
+	})
+
+	It("joins holes that cross the limit into the exterior", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, -20}, {30, -20}, {30, 20}, {0, 20}, {0, -20}},
+			{{10, 5}, {10, 15}, {20, 15}, {20, 5}, {10, 5}},
+			{{10, -5}, {10, -2}, {20, -2}, {20, -5}, {10, -5}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithLatitudeLimit(10))
+		Expect(err).To(BeNil())
+		Expect(antimeridian.Verify(result)).To(BeEmpty())
+
+		polygons := polygonsOf(result)
+		Expect(polygons).To(HaveLen(1))
+		Expect(polygons[0].NumLinearRings()).To(Equal(2))
+		Expect(polygonArea(polygons[0])).To(BeNumerically("~", 600-50-30))
+	})
+
+	It("clips Web Mercator geometries at the projected limit", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 15e6}, {15e6, 15e6}, {-15e6, 15e6}, {0, 15e6}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon,
+			antimeridian.WithCoordinateSystem(antimeridian.WebMercator),
+			antimeridian.WithLatitudeLimit(80),
+		)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(ContainElement(BeNumerically("~", 15538711.1, 0.1)))
+	})
+})
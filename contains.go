@@ -50,8 +50,16 @@ func Contains(polygon *geom.Polygon, containedBy *geom.Polygon) bool {
 	return within
 }
 
-// ContainsPoint checks if the point pt is within the linear ring
-func ContainsPoint(pt geom.Coord, ring *geom.LinearRing) bool {
+// ContainsPoint checks if the point pt is within the linear ring. The point
+// and the ring are expected to share the axis order given by WithAxisOrder,
+// which is the only option that ContainsPoint takes notice of, so that it can
+// be called with the same options as Cut.
+func ContainsPoint(pt geom.Coord, ring *geom.LinearRing, opts ...Option) bool {
+	if newOptions(opts...).axisOrder.resolve(ring.FlatCoords(), ring.Stride()) == LatLon {
+		pt = geom.Coord{pt[1], pt[0]}
+		ring = swapRing(ring)
+	}
+
 	coords := ring.Coords()
 	if len(coords) < 3 {
 		return false
@@ -73,3 +81,11 @@ func rayIntersectsSegment(p, a, b geom.Coord) bool {
 	return (a[1] > p[1]) != (b[1] > p[1]) &&
 		p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0]
 }
+
+// swapRing returns a copy of ring with the first two ordinates of every
+// coordinate swapped
+func swapRing(ring *geom.LinearRing) *geom.LinearRing {
+	swapped := ring.Clone()
+	swapAxes(swapped.FlatCoords(), swapped.Stride())
+	return swapped
+}
@@ -0,0 +1,61 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import "math"
+
+// mercatorHalfWidth is half the width of the world in Web Mercator metres,
+// which is also the y of its latitude limit of about ±85.0511
+const mercatorHalfWidth = math.Pi * 6378137
+
+// mercatorPrecision is the number of decimal places that Web Mercator
+// coordinates are rounded to when they are moved back out of the working frame
+const mercatorPrecision = 6
+
+// CoordinateSystem is the coordinate system that the coordinates of a
+// geometry are in
+type CoordinateSystem int
+
+const (
+	// Geographic coordinates are longitudes and latitudes in degrees. It is
+	// the default.
+	Geographic CoordinateSystem = iota
+	// WebMercator coordinates are EPSG:3857 metres. The seam is at
+	// x = ±20037508.34 and the poles are clamped to the latitude limit of the
+	// projection at y = ±20037508.34.
+	WebMercator
+)
+
+// extent returns the largest x and y of the coordinate system, which are
+// mapped onto the antimeridian and the poles. Web Mercator is square, so its
+// latitude limit is mapped onto the poles.
+func (cs CoordinateSystem) extent() (x, y float64) {
+	if cs == WebMercator {
+		return mercatorHalfWidth, mercatorHalfWidth
+	}
+
+	return 180, 90
+}
+
+// precision returns the number of decimal places that coordinates are rounded
+// to when they are moved out of the working frame
+func (cs CoordinateSystem) precision() uint {
+	if cs == WebMercator {
+		return mercatorPrecision
+	}
+
+	return shiftPrecision
+}
@@ -0,0 +1,94 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"errors"
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+const halfWidth = math.Pi * 6378137
+
+var _ = Describe("Web Mercator", func() {
+	mercator := antimeridian.WithCoordinateSystem(antimeridian.WebMercator)
+
+	It("cuts at the seam of the projection", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{19e6, 0}, {-19e6, 0}, {-19e6, 1e6}, {19e6, 1e6}, {19e6, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, mercator)
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(2))
+		for idx := range multiPolygon.NumPolygons() {
+			coords := multiPolygon.Polygon(idx).LinearRing(0).Coords()
+			Expect(coords).To(ContainElement(BeEquivalentTo(geom.Coord{math.Copysign(halfWidth, coords[1][0]), 0})))
+		}
+	})
+
+	It("puts the crossings of horizontal edges at their y", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{19e6, 0}, {-19e6, 0}, {-19e6, 1e6}, {19e6, 1e6}, {19e6, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, mercator)
+		Expect(err).To(BeNil())
+		for _, piece := range polygonsOf(result) {
+			Expect(piece.LinearRing(0).Coords()).To(ContainElement(
+				BeEquivalentTo(geom.Coord{math.Copysign(halfWidth, piece.FlatCoords()[0]), 1e6}),
+			))
+		}
+	})
+
+	It("leaves the coordinates of polygons that are not cut alone", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{1000.5, 2000.25}, {3000.5, 2000.25}, {3000.5, 4000.75}, {1000.5, 2000.25}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, mercator)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal(polygon.FlatCoords()))
+	})
+
+	It("clamps pole edges to the latitude limit", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 15e6}, {15e6, 15e6}, {-15e6, 15e6}, {0, 15e6}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, mercator)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(ContainElement(halfWidth))
+		for idx := 1; idx < len(result.FlatCoords()); idx += 2 {
+			Expect(math.Abs(result.FlatCoords()[idx])).To(BeNumerically("<=", halfWidth))
+		}
+	})
+
+	It("validates against the latitude limit", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {1e6, 0}, {1e6, 3e7}, {0, 0}},
+		})
+
+		_, err := antimeridian.CutWithOptions(polygon, mercator)
+		Expect(errors.Is(err, antimeridian.ErrLatitudeOutOfRange)).To(BeTrue())
+	})
+})
@@ -0,0 +1,272 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// appendDensified appends the points needed between from and to so that no
+// step along the straight line between them is longer than step degrees.
+// Neither from nor to is appended. Any extra ordinates are interpolated. No
+// points are added if step is not positive.
+func appendDensified(dst, from, to []float64, step float64) []float64 {
+	if step <= 0 {
+		return dst
+	}
+
+	length := math.Hypot(to[0]-from[0], to[1]-from[1])
+	numSteps := int(math.Ceil(length / step))
+	for idx := 1; idx < numSteps; idx++ {
+		dst = append(dst, interpolateCoord(from, to, float64(idx)/float64(numSteps))...)
+	}
+
+	return dst
+}
+
+// MetresPerDegree is the length of a degree of arc on a sphere with the mean
+// radius of the Earth. Divide a distance in metres by it to get the degrees
+// that Densify and WithDensify take.
+const MetresPerDegree = 6371008.8 * math.Pi / 180
+
+// DensifyMode is the path that Densify follows between two vertices
+type DensifyMode int
+
+const (
+	// GreatCircle follows the shortest path on the sphere
+	GreatCircle DensifyMode = iota
+	// RhumbLine follows a line of constant bearing, which is straight in
+	// Mercator
+	RhumbLine
+)
+
+// Densify adds vertices to every edge of a polygon, multi-polygon, line string
+// or multi-line string so that no step along the edge is longer than
+// maxSegment degrees of arc, following great circles or rhumb lines as chosen
+// by mode. Edges in longitude and latitude do not follow the path between
+// their vertices on the globe, which matters for where, and whether, they
+// cross the antimeridian. Every edge takes the shorter way around the globe.
+//
+// The new vertices have longitudes in [-180, 180] unless one of the vertices
+// of their edge is outside of that range, in which case they carry on from
+// the first vertex of the edge. Any extra ordinates are interpolated. obj is
+// not modified.
+func Densify(obj geom.T, maxSegment float64, mode DensifyMode) (geom.T, error) {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		flatCoords, ends := densifyLines(geometry.FlatCoords(), geometry.Ends(), geometry.Stride(), maxSegment, mode)
+		return geom.NewPolygonFlat(geometry.Layout(), flatCoords, ends), nil
+	case *geom.MultiPolygon:
+		flatCoords := make([]float64, 0, len(geometry.FlatCoords()))
+		endss := make([][]int, len(geometry.Endss()))
+		offset := 0
+		for idx, ends := range geometry.Endss() {
+			rings := splitRings(geometry.FlatCoords(), offset, ends)
+			endss[idx] = make([]int, len(rings))
+			for ringIdx, ring := range rings {
+				flatCoords = appendDensifiedLine(flatCoords, ring, geometry.Stride(), maxSegment, mode)
+				endss[idx][ringIdx] = len(flatCoords)
+			}
+
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+
+		return geom.NewMultiPolygonFlat(geometry.Layout(), flatCoords, endss), nil
+	case *geom.LineString:
+		flatCoords := appendDensifiedLine(nil, geometry.FlatCoords(), geometry.Stride(), maxSegment, mode)
+		return geom.NewLineStringFlat(geometry.Layout(), flatCoords), nil
+	case *geom.MultiLineString:
+		flatCoords, ends := densifyLines(geometry.FlatCoords(), geometry.Ends(), geometry.Stride(), maxSegment, mode)
+		return geom.NewMultiLineStringFlat(geometry.Layout(), flatCoords, ends), nil
+	default:
+		return obj, ErrUnsupportedType
+	}
+}
+
+// densifyLines densifies each of the lines of flatCoords ending at ends
+func densifyLines(flatCoords []float64, ends []int, stride int, maxSegment float64, mode DensifyMode) ([]float64, []int) {
+	densified := make([]float64, 0, len(flatCoords))
+	newEnds := make([]int, len(ends))
+	for idx, line := range splitRings(flatCoords, 0, ends) {
+		densified = appendDensifiedLine(densified, line, stride, maxSegment, mode)
+		newEnds[idx] = len(densified)
+	}
+
+	return densified, newEnds
+}
+
+// appendDensifiedLine appends the densified line to dst
+func appendDensifiedLine(dst, line []float64, stride int, maxSegment float64, mode DensifyMode) []float64 {
+	for idx := 0; idx < len(line); idx += stride {
+		if idx > 0 {
+			dst = appendGeodesic(dst, line[idx-stride:idx], line[idx:idx+stride], maxSegment, mode)
+		}
+		dst = append(dst, line[idx:idx+stride]...)
+	}
+
+	return dst
+}
+
+// appendGeodesic appends the points needed between from and to, which are
+// longitude and latitude, so that no step along the path between them is
+// longer than maxSegment degrees of arc. Neither from nor to is appended.
+func appendGeodesic(dst, from, to []float64, maxSegment float64, mode DensifyMode) []float64 {
+	if maxSegment <= 0 {
+		return dst
+	}
+
+	var (
+		length float64
+		at     func(fraction float64) (lon, lat float64)
+	)
+	if mode == RhumbLine {
+		length, at = rhumbLine(from, to)
+	} else {
+		length, at = greatCircle(from, to)
+	}
+
+	numSteps := int(math.Ceil(length / maxSegment))
+	if at == nil || numSteps < 2 {
+		return dst
+	}
+
+	inRange := math.Abs(from[0]) <= 180 && math.Abs(to[0]) <= 180
+	prevLon := from[0]
+	for idx := 1; idx < numSteps; idx++ {
+		fraction := float64(idx) / float64(numSteps)
+		lon, lat := at(fraction)
+
+		// keep the longitudes continuous from the start of the edge
+		delta := lon - prevLon
+		lon = prevLon + delta - 360*math.Round(delta/360)
+		prevLon = lon
+		if inRange && math.Abs(lon) > 180 {
+			lon = mod(lon+180, 360) - 180
+		}
+
+		coord := interpolateCoord(from, to, fraction)
+		coord[0], coord[1] = lon, lat
+		dst = append(dst, coord...)
+	}
+
+	return dst
+}
+
+// greatCircle returns the length, in degrees of arc, of the great circle from
+// a to b and a function giving the point a fraction of the way along it. The
+// function is nil if the path is not defined because a and b are antipodal.
+func greatCircle(a, b []float64) (float64, func(float64) (float64, float64)) {
+	ax, ay, az := unitVector(a)
+	bx, by, bz := unitVector(b)
+
+	// the angle between the vectors, computed in a way that is accurate for
+	// small angles
+	cx, cy, cz := ay*bz-az*by, az*bx-ax*bz, ax*by-ay*bx
+	angle := math.Atan2(math.Sqrt(cx*cx+cy*cy+cz*cz), ax*bx+ay*by+az*bz)
+	sinAngle := math.Sin(angle)
+	if sinAngle < 1e-12 {
+		return toDegrees(angle), nil
+	}
+
+	return toDegrees(angle), func(fraction float64) (float64, float64) {
+		wa := math.Sin((1-fraction)*angle) / sinAngle
+		wb := math.Sin(fraction*angle) / sinAngle
+		x, y, z := wa*ax+wb*bx, wa*ay+wb*by, wa*az+wb*bz
+		return toDegrees(math.Atan2(y, x)), toDegrees(math.Atan2(z, math.Hypot(x, y)))
+	}
+}
+
+// rhumbLine returns the length, in degrees of arc, of the rhumb line from a to
+// b and a function giving the point a fraction of the way along it. The
+// function is nil if the rhumb line is not defined because a or b is a pole.
+func rhumbLine(a, b []float64) (float64, func(float64) (float64, float64)) {
+	if math.Abs(a[1]) == 90 || math.Abs(b[1]) == 90 {
+		return 0, nil
+	}
+
+	dLon := b[0] - a[0]
+	dLon -= 360 * math.Round(dLon/360)
+	dLat := b[1] - a[1]
+
+	psiA, psiB := mercatorPsi(a[1]), mercatorPsi(b[1])
+	dPsi := psiB - psiA
+
+	// q stretches longitude to distance along the line, it is the cosine of
+	// the latitude for lines of constant latitude
+	q := math.Cos(toRadians(a[1]))
+	if math.Abs(dPsi) > 1e-12 {
+		q = toRadians(dLat) / dPsi
+	}
+
+	return math.Hypot(dLat, q*dLon), func(fraction float64) (float64, float64) {
+		lat := a[1] + fraction*dLat
+		if math.Abs(dPsi) <= 1e-12 {
+			return a[0] + fraction*dLon, lat
+		}
+
+		return a[0] + dLon*(mercatorPsi(lat)-psiA)/dPsi, lat
+	}
+}
+
+// unitVector returns the point at the longitude and latitude of coord on the
+// unit sphere
+func unitVector(coord []float64) (x, y, z float64) {
+	lon, lat := toRadians(coord[0]), toRadians(coord[1])
+	return math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)
+}
+
+// mercatorPsi returns the Mercator y, on the unit sphere, of a latitude
+func mercatorPsi(lat float64) float64 {
+	return math.Log(math.Tan(math.Pi/4 + toRadians(lat)/2))
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// densifyRings densifies rings, which are in the working frame, for
+// WithDensify
+func (c *cutter) densifyRings(rings [][]float64, stride int) [][]float64 {
+	mercator := c.opts.coordinateSystem == WebMercator
+	densified := make([][]float64, len(rings))
+	for idx, ring := range rings {
+		if mercator {
+			ring = slices.Clone(ring)
+			for ii := 1; ii < len(ring); ii += stride {
+				ring[ii] = mercatorLat(ring[ii] * c.frame.scaleY)
+			}
+		}
+
+		densified[idx] = appendDensifiedLine(nil, ring, stride, c.opts.densifyMax, c.opts.densifyMode)
+
+		if mercator {
+			for ii := 1; ii < len(densified[idx]); ii += stride {
+				densified[idx][ii] = mercatorY(densified[idx][ii]) / c.frame.scaleY
+			}
+		}
+	}
+
+	return densified
+}
@@ -0,0 +1,193 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+// isSubsequence checks if every coordinate of sub appears in coords, in order
+func isSubsequence(sub, coords []geom.Coord) bool {
+	idx := 0
+	for _, coord := range coords {
+		if idx < len(sub) && coord.Equal(geom.XY, sub[idx]) {
+			idx++
+		}
+	}
+
+	return idx == len(sub)
+}
+
+var _ = Describe("Seam densification", func() {
+	DescribeTable("densifies the edges added by cutting",
+		func(name string, opts ...antimeridian.Option) {
+			obj := readInput(name)
+
+			expected, err := antimeridian.CutWithOptions(obj, opts...)
+			Expect(err).To(BeNil())
+
+			result, err := antimeridian.CutWithOptions(obj, append(opts, antimeridian.WithSeamDensification(1))...)
+			Expect(err).To(BeNil())
+			Expect(antimeridian.Verify(result)).To(BeEmpty())
+
+			expectedPolygons, polygons := polygonsOf(expected), polygonsOf(result)
+			Expect(polygons).To(HaveLen(len(expectedPolygons)))
+			for idx, polygon := range polygons {
+				coords := polygon.LinearRing(0).Coords()
+				Expect(isSubsequence(expectedPolygons[idx].LinearRing(0).Coords(), coords)).To(BeTrue())
+
+				for ii := 1; ii < len(coords); ii++ {
+					a, b := coords[ii-1], coords[ii]
+					onSeam := math.Abs(a.X()) == 180 && a.X() == b.X()
+					onPole := math.Abs(a.Y()) == 90 && a.Y() == b.Y()
+					if onSeam || onPole {
+						Expect(math.Hypot(b.X()-a.X(), b.Y()-a.Y())).To(BeNumerically("<=", 1+1e-9))
+					}
+				}
+			}
+		},
+		Entry("split", "split"),
+		Entry("north pole", "north-pole"),
+		Entry("south pole", "south-pole"),
+		Entry("clockwise without fixing the winding", "cw-only", antimeridian.WithFixWinding(false)),
+		Entry("clipped", "north-pole", antimeridian.WithLatitudeLimit(antimeridian.MercatorLatitudeLimit)),
+	)
+
+	It("leaves polygons that are not cut alone", func() {
+		obj := readInput("simple")
+
+		result, err := antimeridian.CutWithOptions(obj, antimeridian.WithSeamDensification(1))
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(obj))
+	})
+})
+
+var _ = Describe("Densify", func() {
+	It("adds points along great circles", func() {
+		line := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 90, 0})
+
+		result, err := antimeridian.Densify(line, 9, antimeridian.GreatCircle)
+		Expect(err).To(BeNil())
+
+		coords := result.(*geom.LineString).Coords()
+		Expect(coords).To(HaveLen(11))
+		for idx, coord := range coords {
+			Expect(coord.X()).To(BeNumerically("~", float64(idx)*9, 1e-9))
+			Expect(coord.Y()).To(BeNumerically("~", 0, 1e-9))
+		}
+	})
+
+	It("follows great circles across the antimeridian", func() {
+		line := geom.NewLineStringFlat(geom.XY, []float64{170, 60, -170, 60})
+
+		result, err := antimeridian.Densify(line, 1, antimeridian.GreatCircle)
+		Expect(err).To(BeNil())
+
+		coords := result.(*geom.LineString).Coords()
+		Expect(len(coords)).To(BeNumerically(">", 2))
+		for _, coord := range coords[1 : len(coords)-1] {
+			Expect(math.Abs(coord.X())).To(BeNumerically(">=", 170))
+			Expect(coord.Y()).To(BeNumerically(">", 60))
+		}
+	})
+
+	It("follows rhumb lines along parallels", func() {
+		line := geom.NewLineStringFlat(geom.XY, []float64{170, 60, -170, 60})
+
+		result, err := antimeridian.Densify(line, 1, antimeridian.RhumbLine)
+		Expect(err).To(BeNil())
+
+		coords := result.(*geom.LineString).Coords()
+		Expect(len(coords)).To(BeNumerically(">", 2))
+		for _, coord := range coords {
+			Expect(math.Abs(coord.X())).To(BeNumerically(">=", 170))
+			Expect(coord.Y()).To(BeNumerically("~", 60, 1e-9))
+		}
+	})
+
+	It("keeps longitudes continuous outside of [-180, 180]", func() {
+		line := geom.NewLineStringFlat(geom.XY, []float64{170, 0, 190, 0})
+
+		result, err := antimeridian.Densify(line, 5, antimeridian.RhumbLine)
+		Expect(err).To(BeNil())
+
+		coords := result.(*geom.LineString).Coords()
+		Expect(coords).To(HaveLen(5))
+		for idx, coord := range coords {
+			Expect(coord.X()).To(BeNumerically("~", 170+float64(idx)*5, 1e-9))
+		}
+	})
+
+	It("keeps the rings of multi-polygons", func() {
+		obj := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+			{{{20, 0}, {30, 0}, {30, 10}, {20, 10}, {20, 0}}, {{22, 2}, {22, 4}, {24, 4}, {24, 2}, {22, 2}}},
+		})
+
+		result, err := antimeridian.Densify(obj, 1, antimeridian.GreatCircle)
+		Expect(err).To(BeNil())
+
+		multi := result.(*geom.MultiPolygon)
+		Expect(multi.NumPolygons()).To(Equal(2))
+		Expect(multi.Polygon(1).NumLinearRings()).To(Equal(2))
+		Expect(multi.Polygon(0).LinearRing(0).NumCoords()).To(BeNumerically(">", 40))
+		Expect(antimeridian.Verify(multi)).To(BeEmpty())
+	})
+
+	It("rejects unsupported geometries", func() {
+		_, err := antimeridian.Densify(geom.NewPointFlat(geom.XY, []float64{0, 0}), 1, antimeridian.GreatCircle)
+		Expect(err).To(MatchError(antimeridian.ErrUnsupportedType))
+	})
+
+	It("cuts where great circles cross the antimeridian", func() {
+		obj := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 50}, {-170, 50}, {-170, 60}, {170, 60}, {170, 50}},
+		})
+
+		plain, err := antimeridian.CutWithOptions(obj)
+		Expect(err).To(BeNil())
+		Expect(plain.Bounds().Max(1)).To(Equal(60.0))
+
+		result, err := antimeridian.CutWithOptions(obj, antimeridian.WithDensify(1, antimeridian.GreatCircle))
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(2))
+		Expect(result.Bounds().Max(1)).To(BeNumerically(">", 60))
+		Expect(antimeridian.Verify(result)).To(BeEmpty())
+	})
+
+	It("densifies Web Mercator geometries in degrees", func() {
+		const halfWidth = math.Pi * 6378137
+		obj := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{halfWidth - 1e6, 0}, {-halfWidth + 1e6, 0}, {-halfWidth + 1e6, 1e6}, {halfWidth - 1e6, 1e6}, {halfWidth - 1e6, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(obj,
+			antimeridian.WithCoordinateSystem(antimeridian.WebMercator),
+			antimeridian.WithDensify(1, antimeridian.RhumbLine))
+		Expect(err).To(BeNil())
+
+		bounds := result.Bounds()
+		Expect(bounds.Min(1)).To(BeNumerically("~", 0, 1e-6))
+		Expect(bounds.Max(1)).To(BeNumerically("~", 1e6, 1e-6))
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(2))
+		Expect(result.(*geom.MultiPolygon).Polygon(0).LinearRing(0).NumCoords()).To(BeNumerically(">", 5))
+	})
+})
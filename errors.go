@@ -0,0 +1,91 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnsupportedType   = errors.New("unsupported geometry type")
+	ErrUnsupportedLayout = errors.New("unsupported geometry layout")
+
+	ErrRingTooShort        = errors.New("ring has fewer than four coordinates")
+	ErrRingNotClosed       = errors.New("ring is not closed")
+	ErrInvalidCoordinate   = errors.New("coordinate is not a finite number")
+	ErrLatitudeOutOfRange  = errors.New("latitude is outside of [-90, 90]")
+	ErrMultipleRevolutions = errors.New("ring winds around the globe more than once")
+)
+
+// Error describes where in a geometry, and why, processing failed. Err holds
+// the underlying cause, which is usually one of the sentinel errors of this
+// package and can be matched with errors.Is.
+type Error struct {
+	// Polygon is the index of the multi-polygon member, or 0 for a polygon
+	Polygon int
+	// Ring is the index of the ring within the polygon, or -1 if the error
+	// does not relate to a single ring
+	Ring int
+	// Vertex is the index of the vertex within the ring, or -1 if the error
+	// does not relate to a single vertex
+	Vertex int
+	// Reason is a human readable explanation of the failure
+	Reason string
+	// Err is the underlying error
+	Err error
+}
+
+func (e *Error) Error() string {
+	var msg strings.Builder
+
+	fmt.Fprintf(&msg, "polygon %d", e.Polygon)
+	if e.Ring >= 0 {
+		fmt.Fprintf(&msg, ", ring %d", e.Ring)
+	}
+	if e.Vertex >= 0 {
+		fmt.Fprintf(&msg, ", vertex %d", e.Vertex)
+	}
+
+	if e.Reason != "" {
+		msg.WriteString(": ")
+		msg.WriteString(e.Reason)
+	}
+
+	if e.Err != nil {
+		msg.WriteString(": ")
+		msg.WriteString(e.Err.Error())
+	}
+
+	return msg.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError returns an error located at the given ring and vertex of the
+// polygon currently being cut
+func (c *cutter) newError(ring, vertex int, err error, reason string) error {
+	return &Error{
+		Polygon: c.polygon,
+		Ring:    ring,
+		Vertex:  vertex,
+		Reason:  reason,
+		Err:     err,
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"errors"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Errors", func() {
+	It("matches the sentinel errors and reports the location", func() {
+		multiPolygon := geom.NewMultiPolygon(geom.XYM).MustSetCoords([][][]geom.Coord{
+			{{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 0, 0}}},
+		})
+
+		_, err := antimeridian.Cut(multiPolygon)
+		Expect(errors.Is(err, antimeridian.ErrUnsupportedLayout)).To(BeTrue())
+
+		var cutErr *antimeridian.Error
+		Expect(errors.As(err, &cutErr)).To(BeTrue())
+		Expect(cutErr.Polygon).To(Equal(0))
+		Expect(cutErr.Ring).To(Equal(-1))
+		Expect(cutErr.Vertex).To(Equal(-1))
+	})
+
+	It("describes the location in the message", func() {
+		err := &antimeridian.Error{
+			Polygon: 3,
+			Ring:    1,
+			Vertex:  17,
+			Reason:  "something went wrong",
+			Err:     antimeridian.ErrUnsupportedLayout,
+		}
+
+		Expect(err.Error()).To(Equal("polygon 3, ring 1, vertex 17: something went wrong: unsupported geometry layout"))
+		Expect(errors.Is(err, antimeridian.ErrUnsupportedLayout)).To(BeTrue())
+	})
+})
@@ -0,0 +1,98 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import "slices"
+
+// The functions in this file work on coordinates in go-geom's flat
+// representation where each coordinate takes up stride consecutive values.
+
+// splitRings returns the rings of a polygon as sub-slices of flatCoords. ends
+// are absolute offsets into flatCoords starting at offset.
+func splitRings(flatCoords []float64, offset int, ends []int) [][]float64 {
+	rings := make([][]float64, len(ends))
+	for idx, end := range ends {
+		rings[idx] = flatCoords[offset:end:end]
+		offset = end
+	}
+
+	return rings
+}
+
+// flattenPolygons packs polygons, each a list of flat rings, into a single
+// flat coordinate slice with the matching ends for each polygon
+func flattenPolygons(polygons [][][]float64) ([]float64, [][]int) {
+	numValues, numRings := 0, 0
+	for _, rings := range polygons {
+		numRings += len(rings)
+		for _, ring := range rings {
+			numValues += len(ring)
+		}
+	}
+
+	flatCoords := make([]float64, 0, numValues)
+	endsBuf := make([]int, 0, numRings)
+	endss := make([][]int, len(polygons))
+	for idx, rings := range polygons {
+		start := len(endsBuf)
+		for _, ring := range rings {
+			flatCoords = append(flatCoords, ring...)
+			endsBuf = append(endsBuf, len(flatCoords))
+		}
+
+		endss[idx] = endsBuf[start:len(endsBuf):len(endsBuf)]
+	}
+
+	return flatCoords, endss
+}
+
+// coordAt returns the idx-th coordinate of flatCoords
+func coordAt(flatCoords []float64, idx, stride int) []float64 {
+	return flatCoords[idx*stride : (idx+1)*stride : (idx+1)*stride]
+}
+
+// lastCoord returns the final coordinate of flatCoords
+func lastCoord(flatCoords []float64, stride int) []float64 {
+	return flatCoords[len(flatCoords)-stride:]
+}
+
+// appendCoord appends a coordinate with the given x and y to dst. Any
+// remaining ordinates are copied from extra.
+func appendCoord(dst []float64, x, y float64, extra []float64) []float64 {
+	dst = append(dst, x, y)
+	if len(extra) > 2 {
+		dst = append(dst, extra[2:]...)
+	}
+
+	return dst
+}
+
+// reverseFlat reverses the order of the coordinates in flatCoords in place
+func reverseFlat(flatCoords []float64, stride int) {
+	for ii, jj := 0, len(flatCoords)-stride; ii < jj; ii, jj = ii+stride, jj-stride {
+		for kk := range stride {
+			flatCoords[ii+kk], flatCoords[jj+kk] = flatCoords[jj+kk], flatCoords[ii+kk]
+		}
+	}
+}
+
+// reversedFlat returns a copy of flatCoords with the coordinates in reverse
+// order
+func reversedFlat(flatCoords []float64, stride int) []float64 {
+	reversed := slices.Clone(flatCoords)
+	reverseFlat(reversed, stride)
+	return reversed
+}
@@ -0,0 +1,231 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// shiftPrecision is the number of decimal places that longitudes are rounded
+// to when they are moved back out of the working frame, so that shifting does
+// not introduce floating point noise
+const shiftPrecision = 10
+
+// frame describes how the coordinates of a geometry map onto the working frame
+// that cutting is done in, where longitude comes first and the seam is at
+// ±180
+type frame struct {
+	// swap is set for latitude first input
+	swap bool
+	// restore swaps the output back to latitude first
+	restore bool
+	// shift is how far east of 180 the seam is. The output is in
+	// [shift-180, shift+180].
+	shift float64
+	// scaleX and scaleY are the number of units of the coordinate system in
+	// a degree of the working frame
+	scaleX, scaleY float64
+	// halfX and halfY are the extent of the coordinate system, which maps to
+	// ±180 and ±90 in the working frame
+	halfX, halfY float64
+	// precision is the number of decimal places coordinates are rounded to
+	// when they are moved out of the working frame
+	precision uint
+	// inferSeamSide is set when longitudes on the seam can't say which side of
+	// it they are on, because the input convention does not have separate
+	// values for each side of it. The side is then taken from the previous
+	// vertex.
+	inferSeamSide bool
+	// input is the longitude convention of the input
+	input LonConvention
+}
+
+// frameOf returns the frame for a geometry with the given coordinates
+func (c *cutter) frameOf(flatCoords []float64, stride int) frame {
+	f := frame{swap: c.opts.axisOrder.resolve(flatCoords, stride) == LatLon}
+	f.restore = f.swap && c.opts.restoreAxisOrder
+
+	input := c.opts.lonConvention
+	if input == AutoLon {
+		lonIdx := 0
+		if f.swap {
+			lonIdx = 1
+		}
+		input = detectLonConvention(flatCoords, stride, lonIdx)
+	}
+
+	output := c.opts.outputLonConvention
+	if output == AutoLon {
+		output = input
+	}
+
+	f.halfX, f.halfY = c.opts.coordinateSystem.extent()
+	f.scaleX, f.scaleY = f.halfX/180, f.halfY/90
+	f.precision = c.opts.coordinateSystem.precision()
+
+	meridian := output.wrapsAt()
+	if c.opts.hasMeridian {
+		meridian = c.opts.meridian / f.scaleX
+	}
+
+	f.shift = meridian - 180
+	f.inferSeamSide = mod(meridian-input.wrapsAt(), 360) != 0
+	f.input = input
+
+	return f
+}
+
+// isIdentity reports if the coordinates of the geometry can be used as they
+// are
+func (f frame) isIdentity() bool {
+	return !f.swap && f.shift == 0 && !f.isScaled()
+}
+
+// isScaled reports if the coordinate system has to be scaled to degrees
+func (f frame) isScaled() bool {
+	return f.scaleX != 1 || f.scaleY != 1
+}
+
+// toWork moves flatCoords into the working frame in place
+func (f frame) toWork(flatCoords []float64, stride int) {
+	if f.swap {
+		swapAxes(flatCoords, stride)
+	}
+
+	if f.isScaled() {
+		for idx := 0; idx < len(flatCoords); idx += stride {
+			flatCoords[idx] /= f.scaleX
+			flatCoords[idx+1] /= f.scaleY
+		}
+	}
+
+	if f.shift != 0 {
+		for idx := 0; idx < len(flatCoords); idx += stride {
+			flatCoords[idx] -= f.shift
+		}
+	}
+}
+
+// fromWork moves flatCoords out of the working frame in place
+func (f frame) fromWork(flatCoords []float64, stride int) {
+	if f.shift != 0 || f.isScaled() {
+		for idx := 0; idx < len(flatCoords); idx += stride {
+			flatCoords[idx] = f.unscale(flatCoords[idx], 180, f.shift, f.scaleX, f.halfX)
+		}
+	}
+
+	if f.isScaled() {
+		for idx := 0; idx < len(flatCoords); idx += stride {
+			flatCoords[idx+1] = f.unscale(flatCoords[idx+1], 90, 0, f.scaleY, f.halfY)
+		}
+	}
+
+	if f.restore {
+		swapAxes(flatCoords, stride)
+	}
+}
+
+// inputLon moves a longitude out of the working frame into the longitudes of
+// the input
+func (f frame) inputLon(lon float64) float64 {
+	if f.shift == 0 && !f.isScaled() {
+		return lon
+	}
+
+	return roundFloat((lon+f.shift)*f.scaleX, f.precision)
+}
+
+// unscale moves a single ordinate out of the working frame. Ordinates on the
+// edge of the working frame, at limit, are put exactly on the edge of the
+// coordinate system, at half, so that the seam and the poles do not pick up
+// rounding errors.
+func (f frame) unscale(val, limit, shift, scale, half float64) float64 {
+	if math.Abs(val) == limit {
+		return math.Copysign(half, val) + shift*scale
+	}
+
+	return roundFloat((val+shift)*scale, f.precision)
+}
+
+// transformGeometry applies transform to the coordinates of a polygon or
+// multi-polygon. The geometry is copied first unless inPlace is set. Other
+// geometries are returned as they are.
+func transformGeometry(obj geom.T, inPlace bool, transform func(flatCoords []float64, stride int)) geom.T {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		if !inPlace {
+			geometry = geometry.Clone()
+		}
+
+		transform(geometry.FlatCoords(), geometry.Stride())
+		return geometry
+	case *geom.MultiPolygon:
+		if !inPlace {
+			geometry = geometry.Clone()
+		}
+
+		transform(geometry.FlatCoords(), geometry.Stride())
+		return geometry
+	default:
+		return obj
+	}
+}
+
+// cut divides obj at the antimeridian and the poles
+func (c *cutter) cut(obj geom.T) (geom.T, error) {
+	// cutters are reused across geometries, errors for a polygon must not
+	// carry the member index of an earlier multi-polygon
+	c.polygon = 0
+	if obj == nil {
+		return c.cutGeometry(obj)
+	}
+
+	c.frame = c.frameOf(obj.FlatCoords(), obj.Stride())
+	if c.frame.isIdentity() {
+		return c.cutGeometry(obj)
+	}
+
+	// the transformed geometry is our own, so the result can always be
+	// transformed back in place
+	result, err := c.cutGeometry(transformGeometry(obj, c.opts.inPlace, c.frame.toWork))
+	if result != nil {
+		result = transformGeometry(result, true, c.frame.fromWork)
+	}
+
+	return result, err
+}
+
+func (c *cutter) cutFlat(layout geom.Layout, flatCoords []float64, ends []int) ([]float64, [][]int, error) {
+	stride := layout.Stride()
+	c.polygon = 0
+	c.frame = c.frameOf(flatCoords, stride)
+	if c.frame.isIdentity() {
+		return c.cutFlatWork(layout, flatCoords, ends)
+	}
+
+	if !c.opts.inPlace {
+		flatCoords = slices.Clone(flatCoords)
+	}
+	c.frame.toWork(flatCoords, stride)
+
+	flatCoords, endss, err := c.cutFlatWork(layout, flatCoords, ends)
+	c.frame.fromWork(flatCoords, stride)
+
+	return flatCoords, endss, err
+}
@@ -0,0 +1,230 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+// maxBands limits the number of horizontal bands a ring index is split into
+// so that long edges do not blow up the size of the index
+const maxBands = 4096
+
+// bbox is an axis aligned bounding box in the x/y plane
+type bbox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+func newBBox(flatCoords []float64, stride int) bbox {
+	box := bbox{
+		MinX: math.Inf(1),
+		MinY: math.Inf(1),
+		MaxX: math.Inf(-1),
+		MaxY: math.Inf(-1),
+	}
+
+	for idx := 0; idx+1 < len(flatCoords); idx += stride {
+		box.MinX = math.Min(box.MinX, flatCoords[idx])
+		box.MaxX = math.Max(box.MaxX, flatCoords[idx])
+		box.MinY = math.Min(box.MinY, flatCoords[idx+1])
+		box.MaxY = math.Max(box.MaxY, flatCoords[idx+1])
+	}
+
+	return box
+}
+
+// containsBBox checks if other falls entirely within box
+func (box bbox) containsBBox(other bbox) bool {
+	return box.MinX <= other.MinX && other.MaxX <= box.MaxX &&
+		box.MinY <= other.MinY && other.MaxY <= box.MaxY
+}
+
+// containsPoint checks if the point (x, y) falls within box
+func (box bbox) containsPoint(x, y float64) bool {
+	return box.MinX <= x && x <= box.MaxX && box.MinY <= y && y <= box.MaxY
+}
+
+// edgeIndex speeds up repeated point in polygon tests against the same set of
+// edges. The edges are bucketed into horizontal bands so that a test only
+// needs to look at the edges that span the latitude of the point.
+type edgeIndex struct {
+	box bbox
+	// edges holds four values, ax, ay, bx and by, for every edge
+	edges      []float64
+	bandHeight float64
+	bands      [][]int
+}
+
+// newRingIndex returns an index of the edges of a ring
+func newRingIndex(flatCoords []float64, stride int) *edgeIndex {
+	numCoords := len(flatCoords) / stride
+	if numCoords < 3 {
+		return newEdgeIndex(nil)
+	}
+
+	// edge idx runs from coordinate idx to coordinate idx+1, wrapping around
+	// to the start of the ring for the last edge
+	edges := make([]float64, 0, 4*numCoords)
+	for idx := range numCoords {
+		next := (idx + 1) % numCoords
+		edges = append(edges,
+			flatCoords[idx*stride], flatCoords[idx*stride+1],
+			flatCoords[next*stride], flatCoords[next*stride+1],
+		)
+	}
+
+	return newEdgeIndex(edges)
+}
+
+// newEdgeIndex returns an index of edges, which holds four values, ax, ay, bx
+// and by, for every edge
+func newEdgeIndex(edges []float64) *edgeIndex {
+	numEdges := len(edges) / 4
+	index := &edgeIndex{
+		box:   newBBox(edges, 2),
+		edges: edges,
+	}
+
+	if numEdges == 0 {
+		return index
+	}
+
+	numBands := min(numEdges/4+1, maxBands)
+	index.bandHeight = (index.box.MaxY - index.box.MinY) / float64(numBands)
+	index.bands = make([][]int, numBands)
+
+	// The edges are counted first so that every band can share the same
+	// backing array.
+	edgeBands := func(idx int) (int, int) {
+		ay, by := edges[4*idx+1], edges[4*idx+3]
+		return index.band(min(ay, by)), index.band(max(ay, by))
+	}
+
+	counts := make([]int, numBands)
+	total := 0
+	for idx := range numEdges {
+		first, last := edgeBands(idx)
+		for band := first; band <= last; band++ {
+			counts[band]++
+		}
+		total += last - first + 1
+	}
+
+	backing := make([]int, total)
+	offset := 0
+	for band, count := range counts {
+		index.bands[band] = backing[offset : offset : offset+count]
+		offset += count
+	}
+
+	for idx := range numEdges {
+		first, last := edgeBands(idx)
+		for band := first; band <= last; band++ {
+			index.bands[band] = append(index.bands[band], idx)
+		}
+	}
+
+	return index
+}
+
+// band returns the band that the latitude y falls in
+func (index *edgeIndex) band(y float64) int {
+	if index.bandHeight == 0 {
+		return 0
+	}
+
+	band := int((y - index.box.MinY) / index.bandHeight)
+	return max(0, min(band, len(index.bands)-1))
+}
+
+// containsPoint checks if the point pt is within the indexed edges using the
+// even-odd rule. For the index of a ring it gives the same answer as
+// ContainsPoint.
+func (index *edgeIndex) containsPoint(pt geom.Coord) bool {
+	if len(index.bands) == 0 || !index.box.containsPoint(pt[0], pt[1]) {
+		return false
+	}
+
+	in := false
+	for _, idx := range index.bands[index.band(pt[1])] {
+		edge := index.edges[4*idx : 4*idx+4]
+		if rayIntersectsSegment(pt, geom.Coord(edge[0:2]), geom.Coord(edge[2:4])) {
+			in = !in
+		}
+	}
+
+	return in
+}
+
+// representativePoint returns a vertex of the ring that can be used to test
+// which polygon the ring belongs to. Vertices on the antimeridian are avoided
+// since they can sit on the boundary of a cut polygon.
+func representativePoint(ring []float64, stride int) geom.Coord {
+	for idx := 0; idx < len(ring); idx += stride {
+		if math.Abs(ring[idx]) != 180 {
+			return ring[idx : idx+2]
+		}
+	}
+
+	return ring[:2]
+}
+
+// assignInteriors adds each interior ring to the first polygon that contains
+// it. Interiors that are not contained by any polygon are dropped. Candidate
+// polygons are found with a bounding box check and then confirmed by testing
+// a single point of the interior against an index of the polygon exterior.
+func (c *cutter) assignInteriors(polygons [][][]float64, interiors [][]float64, stride int) error {
+	if len(polygons) == 0 || len(interiors) == 0 {
+		return nil
+	}
+
+	boxes := make([]bbox, len(polygons))
+	indexes := make([]*edgeIndex, len(polygons))
+	for idx, polygon := range polygons {
+		boxes[idx] = newBBox(polygon[0], stride)
+	}
+
+	for interiorIdx, interior := range interiors {
+		if err := c.cancelled(-1, interiorIdx, len(interiors), "interior rings"); err != nil {
+			return err
+		}
+
+		if len(interior) == 0 {
+			continue
+		}
+
+		interiorBox := newBBox(interior, stride)
+		pt := representativePoint(interior, stride)
+		for idx, polygon := range polygons {
+			if !boxes[idx].containsBBox(interiorBox) {
+				continue
+			}
+
+			if indexes[idx] == nil {
+				indexes[idx] = newRingIndex(polygon[0], stride)
+			}
+
+			if indexes[idx].containsPoint(pt) {
+				polygons[idx] = append(polygons[idx], interior)
+				break
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+)
+
+// insetSeam moves the vertices of polygons that cutting added on the seam of
+// the working frame inward by the seam inset. Vertices on the seam of input,
+// the polygons of the working frame that were cut, are the caller's and are
+// left alone, as are the copies that cutting makes of them on the other side
+// of the seam where an edge crosses it at one of them. They are matched on
+// their exact coordinates, so a vertex added at the same latitude elsewhere is
+// still moved. Vertices at the poles are left alone too, as the edges along
+// the pole between them would otherwise cross the seam. Rings are copied
+// before they are changed, as they can share coordinates with the input.
+func (c *cutter) insetSeam(polygons, input [][][]float64, stride int) {
+	inset := c.opts.seamInset / c.frame.scaleX
+	if inset <= 0 {
+		return
+	}
+
+	kept := make(map[[2]float64]bool)
+	for _, rings := range input {
+		for ringIdx, ring := range rings {
+			if ringIdx == 0 {
+				// the exterior is normalized before it is cut, which can move
+				// its vertices to the other side of the seam
+				ring = c.normalize(ring, stride)
+			}
+			keepSeamVertices(kept, ring, stride)
+		}
+	}
+
+	for _, polygon := range polygons {
+		for ringIdx, ring := range polygon {
+			copied := false
+			for idx := 0; idx < len(ring); idx += stride {
+				if math.Abs(ring[idx]) != 180 || math.Abs(ring[idx+1]) == 90 || kept[[2]float64{ring[idx], ring[idx+1]}] {
+					continue
+				}
+
+				if !copied {
+					ring = slices.Clone(ring)
+					polygon[ringIdx] = ring
+					copied = true
+				}
+				ring[idx] = math.Copysign(180-inset, ring[idx])
+			}
+		}
+	}
+}
+
+// keepSeamVertices adds the vertices of a closed ring that are on the seam to
+// kept, along with their copies on the other side of the seam where an edge
+// to or from them crosses it
+func keepSeamVertices(kept map[[2]float64]bool, ring []float64, stride int) {
+	numCoords := len(ring)/stride - 1
+	for idx := range numCoords {
+		vertex := coordAt(ring, idx, stride)
+		if math.Abs(vertex[0]) != 180 {
+			continue
+		}
+
+		kept[[2]float64{vertex[0], vertex[1]}] = true
+		prev := coordAt(ring, (idx+numCoords-1)%numCoords, stride)
+		next := coordAt(ring, idx+1, stride)
+		if crossingOf(prev, vertex) != noCrossing || crossingOf(vertex, next) != noCrossing {
+			kept[[2]float64{-vertex[0], vertex[1]}] = true
+		}
+	}
+}
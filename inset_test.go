@@ -0,0 +1,125 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Seam inset", func() {
+	DescribeTable("moves vertices off the antimeridian",
+		func(name string) {
+			obj := readInput(name)
+			original := geom.T(nil)
+			switch geometry := obj.(type) {
+			case *geom.Polygon:
+				original = geometry.Clone()
+			case *geom.MultiPolygon:
+				original = geometry.Clone()
+			}
+
+			expected, err := antimeridian.Cut(obj)
+			Expect(err).To(BeNil())
+
+			result, err := antimeridian.CutWithOptions(obj, antimeridian.WithSeamInset(1e-7))
+			Expect(err).To(BeNil())
+			Expect(obj.FlatCoords()).To(Equal(original.FlatCoords()))
+			Expect(antimeridian.Verify(result)).To(BeEmpty())
+
+			// vertices of the input on the antimeridian are not moved
+			inputSeam := make(map[float64]bool)
+			for idx := 0; idx < len(original.FlatCoords()); idx += 2 {
+				if math.Abs(original.FlatCoords()[idx]) == 180 {
+					inputSeam[original.FlatCoords()[idx+1]] = true
+				}
+			}
+
+			flatCoords, expectedCoords := result.FlatCoords(), expected.FlatCoords()
+			Expect(flatCoords).To(HaveLen(len(expectedCoords)))
+			for idx := 0; idx < len(flatCoords); idx += 2 {
+				x, y := flatCoords[idx], flatCoords[idx+1]
+				Expect(y).To(Equal(expectedCoords[idx+1]))
+				if math.Abs(expectedCoords[idx]) == 180 && math.Abs(y) != 90 && !inputSeam[y] {
+					Expect(x).To(Equal(math.Copysign(179.9999999, expectedCoords[idx])))
+				} else {
+					Expect(x).To(Equal(expectedCoords[idx]))
+				}
+			}
+		},
+		Entry("split", "split"),
+		Entry("complex split", "complex-split"),
+		Entry("multi split", "multi-split"),
+		Entry("north pole", "north-pole"),
+		Entry("both poles", "both-poles"),
+		Entry("point on antimeridian", "point-on-antimeridian"),
+	)
+
+	It("leaves vertices of the input on the antimeridian alone", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 0}, {-170, 0}, {-170, 10}, {180, 5}, {170, 10}, {170, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithSeamInset(1e-7))
+		Expect(err).To(BeNil())
+
+		coords := make([]geom.Coord, 0)
+		for _, piece := range polygonsOf(result) {
+			coords = append(coords, piece.LinearRing(0).Coords()...)
+		}
+		Expect(coords).To(ContainElements(geom.Coord{179.9999999, 0}, geom.Coord{-179.9999999, 0}))
+		Expect(coords).To(ContainElement(geom.Coord{180, 5}))
+		Expect(coords).NotTo(ContainElement(geom.Coord{180, 0}))
+	})
+
+	It("moves vertices added at the latitude of one of the input", func() {
+		// the first polygon touches the antimeridian from the east at 5, where
+		// the edge from (170, 0) to (-170, 10) of the second one crosses it
+		multi := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{-180, 5}, {-170, -10}, {-160, -10}, {-180, 5}}},
+			{{{170, 0}, {-170, 10}, {-170, 20}, {170, 20}, {170, 0}}},
+		})
+
+		result, err := antimeridian.CutWithOptions(multi, antimeridian.WithSeamInset(1e-7))
+		Expect(err).To(BeNil())
+
+		coords := make([]geom.Coord, 0)
+		for _, piece := range polygonsOf(result) {
+			coords = append(coords, piece.LinearRing(0).Coords()...)
+		}
+		Expect(coords).To(ContainElements(geom.Coord{-180, 5}, geom.Coord{179.9999999, 5}))
+		Expect(coords).NotTo(ContainElement(geom.Coord{180, 5}))
+	})
+
+	It("insets in the units of the coordinate system", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{19e6, 0}, {-19e6, 0}, {-19e6, 1e6}, {19e6, 1e6}, {19e6, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon,
+			antimeridian.WithCoordinateSystem(antimeridian.WebMercator),
+			antimeridian.WithSeamInset(1))
+		Expect(err).To(BeNil())
+
+		bounds := result.Bounds()
+		Expect(bounds.Min(0)).To(BeNumerically("~", -halfWidth+1, 1e-6))
+		Expect(bounds.Max(0)).To(BeNumerically("~", halfWidth-1, 1e-6))
+	})
+})
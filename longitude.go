@@ -0,0 +1,91 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import "github.com/twpayne/go-geom"
+
+// LonConvention is the range that longitudes are given in
+type LonConvention int
+
+const (
+	// Lon180 gives longitudes in [-180, 180], as GeoJSON does. It is the
+	// default.
+	Lon180 LonConvention = iota
+	// Lon360 gives longitudes in [0, 360], as climate and GRIB data often do
+	Lon360
+	// AutoLon detects the convention of the input with DetectLonConvention.
+	// As an output convention it keeps the convention of the input.
+	AutoLon
+)
+
+// DetectLonConvention guesses the longitude convention of a geometry. It is
+// Lon360 if no longitude is negative and at least one is greater than 180,
+// otherwise it is Lon180.
+func DetectLonConvention(obj geom.T) LonConvention {
+	return detectLonConvention(obj.FlatCoords(), obj.Stride(), 0)
+}
+
+// detectLonConvention detects the convention of the longitudes found at
+// offset lonIdx within each coordinate of flatCoords
+func detectLonConvention(flatCoords []float64, stride, lonIdx int) LonConvention {
+	over180 := false
+	for idx := lonIdx; idx < len(flatCoords); idx += stride {
+		if flatCoords[idx] < 0 {
+			return Lon180
+		}
+
+		over180 = over180 || flatCoords[idx] > 180
+	}
+
+	if over180 {
+		return Lon360
+	}
+
+	return Lon180
+}
+
+// wrapsAt returns the longitude at which the range of the convention ends,
+// and where it wraps around to the start
+func (convention LonConvention) wrapsAt() float64 {
+	if convention == Lon360 {
+		return 360
+	}
+
+	return 180
+}
+
+// Recenter wraps the longitudes of a polygon or multi-polygon into
+// [central-180, central+180] for display on a map centred on the given
+// meridian. Longitudes already in range are left alone and the others are
+// rounded to 10 decimal places. Recenter does not cut the geometry; cut it at
+// the opposite meridian first with CutAt so that no edge crosses the edge of
+// the map. obj is not modified.
+func Recenter(obj geom.T, central float64) (geom.T, error) {
+	switch obj.(type) {
+	case *geom.Polygon, *geom.MultiPolygon:
+	default:
+		return obj, ErrUnsupportedType
+	}
+
+	west, east := central-180, central+180
+	return transformGeometry(obj, false, func(flatCoords []float64, stride int) {
+		for idx := 0; idx < len(flatCoords); idx += stride {
+			if lon := flatCoords[idx]; lon < west || lon > east {
+				flatCoords[idx] = roundFloat(mod(lon-west, 360)+west, shiftPrecision)
+			}
+		}
+	}), nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Longitude conventions", func() {
+	// a 0..360 polygon with a vertex that touches 180 from the west
+	touching := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{170, 0}, {190, 0}, {180, 5}, {190, 10}, {170, 10}, {170, 0}},
+	})
+
+	It("detects the convention from the range of the longitudes", func() {
+		Expect(antimeridian.DetectLonConvention(touching)).To(Equal(antimeridian.Lon360))
+		Expect(antimeridian.DetectLonConvention(readInput("split"))).To(Equal(antimeridian.Lon180))
+	})
+
+	It("puts 0..360 longitudes of 180 on the side of the previous vertex", func() {
+		result, err := antimeridian.Cut(touching)
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(3))
+
+		result, err = antimeridian.CutWithOptions(touching, antimeridian.WithLonConvention(antimeridian.Lon360))
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{
+			180, 10, 170, 10, 170, 0, 180, 0, 180, 10,
+			-180, 0, -170, 0, -180, 5, -170, 10, -180, 10, -180, 0,
+		}))
+	})
+
+	It("keeps 0..360 polygons in their own convention", func() {
+		result, err := antimeridian.CutWithOptions(touching,
+			antimeridian.WithLonConvention(antimeridian.AutoLon),
+			antimeridian.WithOutputLonConvention(antimeridian.AutoLon),
+		)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal(touching.FlatCoords()))
+	})
+
+	DescribeTable("applies the output convention to polygons that are not cut",
+		func(input, output antimeridian.LonConvention, minLon, expected float64) {
+			polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{minLon, 0}, {minLon + 10, 0}, {minLon + 10, 10}, {minLon, 10}, {minLon, 0}},
+			})
+
+			result, err := antimeridian.CutWithOptions(polygon,
+				antimeridian.WithLonConvention(input),
+				antimeridian.WithOutputLonConvention(output),
+			)
+			Expect(err).To(BeNil())
+			Expect(result.FlatCoords()).To(Equal([]float64{
+				expected, 0, expected + 10, 0, expected + 10, 10, expected, 10, expected, 0,
+			}))
+
+			// the input is left untouched
+			Expect(polygon.FlatCoords()[0]).To(Equal(minLon))
+		},
+		Entry("0..360 input with -180..180 output", antimeridian.Lon360, antimeridian.Lon180, 200.0, -160.0),
+		Entry("-180..180 input with 0..360 output", antimeridian.Lon180, antimeridian.Lon360, -20.0, 340.0),
+	)
+
+	It("cuts at the prime meridian for 0..360 output", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-10.1, 0}, {10.3, 0}, {10.3, 10}, {-10.1, 10}, {-10.1, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithOutputLonConvention(antimeridian.Lon360))
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{
+			360, 10, 349.9, 10, 349.9, 0, 360, 0, 360, 10,
+			0, 0, 10.3, 0, 10.3, 10, 0, 10, 0, 0,
+		}))
+	})
+})
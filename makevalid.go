@@ -0,0 +1,552 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// intersectionTolerance is how close to the end of an edge, as a fraction of
+// its length, an intersection has to be to be treated as touching the end
+const intersectionTolerance = 1e-12
+
+// MakeValid resolves self-intersections in a polygon or multi-polygon, such as
+// bowties, producing valid polygons that cover the same area under the
+// even-odd rule. Rings that jump across the antimeridian are unwrapped before
+// they are checked for intersections, so a vertex that has been shifted by
+// 360 degrees does not cause a bowtie, and the longitudes of the result are
+// wrapped back into [-180, 180].
+//
+// The result is wound according to RFC 7946 and can be passed to Cut; it can
+// equally be used on the output of Cut. A polygon that resolves into a single
+// polygon is returned as a *geom.Polygon, otherwise a *geom.MultiPolygon is
+// returned. The members of a multi-polygon are made valid individually.
+// Polygons that wind around a pole are returned unchanged.
+func MakeValid(obj geom.T) (geom.T, error) {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		polygons := makeValid(splitRings(geometry.FlatCoords(), 0, geometry.Ends()), geometry.Stride())
+		flatCoords, endss := flattenPolygons(polygons)
+		if len(endss) == 1 {
+			return geom.NewPolygonFlat(geometry.Layout(), flatCoords, endss[0]), nil
+		}
+
+		return geom.NewMultiPolygonFlat(geometry.Layout(), flatCoords, endss), nil
+	case *geom.MultiPolygon:
+		polygons := make([][][]float64, 0, geometry.NumPolygons())
+		offset := 0
+		for _, ends := range geometry.Endss() {
+			polygons = append(polygons, makeValid(splitRings(geometry.FlatCoords(), offset, ends), geometry.Stride())...)
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+
+		flatCoords, endss := flattenPolygons(polygons)
+		return geom.NewMultiPolygonFlat(geometry.Layout(), flatCoords, endss), nil
+	default:
+		return obj, ErrUnsupportedType
+	}
+}
+
+// makeValid resolves the self-intersections of a single polygon
+func makeValid(rings [][]float64, stride int) [][][]float64 {
+	if len(rings) == 0 {
+		return nil
+	}
+
+	unwrapped, ok := unwrapPolygon(rings, stride)
+	if !ok {
+		// polygons that wind around a pole can't be laid out on a plane
+		return [][][]float64{rings}
+	}
+
+	polygons := planarPolygons(unwrapped, stride)
+	if len(polygons) == 0 && ringArea(unwrapped[0], stride) != 0 {
+		// a ring with an area always has a face, the polygon is returned
+		// as it is rather than being lost
+		return [][][]float64{rings}
+	}
+
+	for _, polygon := range polygons {
+		for _, ring := range polygon {
+			wrapLongitudes(ring, stride)
+		}
+	}
+
+	return polygons
+}
+
+// planarPolygons returns simple polygons covering the area of rings under the
+// even-odd rule, treating longitude and latitude as planar coordinates.
+//
+// The rings are first split at every point where they touch or cross, giving
+// a planar graph. Edges that are shared an even number of times cancel out,
+// what remains is the boundary of the area covered by the rings under the
+// even-odd rule. Each edge is oriented so that the area is on its left and
+// the faces of the graph are traced to give simple rings.
+func planarPolygons(rings [][]float64, stride int) [][][]float64 {
+	graph := newPlanarGraph(stride)
+	graph.addRings(rings)
+	faces := graph.faces()
+
+	// counter-clockwise faces are shells, clockwise faces are holes
+	shells := make([][]int, 0)
+	holes := make([][]int, 0)
+	for _, face := range faces {
+		switch area := ringArea(graph.ring(face), stride); {
+		case area > 0:
+			shells = append(shells, face)
+		case area < 0:
+			holes = append(holes, face)
+		}
+	}
+
+	// holes belong to the smallest shell that contains them
+	slices.SortFunc(shells, func(a, b []int) int {
+		return cmpFloat(math.Abs(ringArea(graph.ring(a), stride)), math.Abs(ringArea(graph.ring(b), stride)))
+	})
+
+	polygons := make([][][]float64, len(shells))
+	indexes := make([]*edgeIndex, len(shells))
+	for idx, shell := range shells {
+		polygons[idx] = [][]float64{graph.ring(shell)}
+		indexes[idx] = newRingIndex(polygons[idx][0], stride)
+	}
+
+	for _, hole := range holes {
+		for idx, shell := range shells {
+			pt, ok := graph.pointNotOn(hole, shell)
+			if ok && indexes[idx].containsPoint(pt) {
+				polygons[idx] = append(polygons[idx], graph.ring(hole))
+				break
+			}
+		}
+	}
+
+	return polygons
+}
+
+// unwrapPolygon returns a copy of rings where each ring has continuous
+// longitudes, i.e. no edge jumps across the antimeridian, and all rings are in
+// the same 360 degree range as the exterior. ok is false if a ring winds
+// around the globe and can't be unwrapped.
+func unwrapPolygon(rings [][]float64, stride int) (unwrapped [][]float64, ok bool) {
+	unwrapped = make([][]float64, len(rings))
+	for idx, ring := range rings {
+		ring, ok := unwrapRing(ring, stride)
+		if !ok {
+			return rings, false
+		}
+
+		unwrapped[idx] = ring
+	}
+
+	exterior := newBBox(unwrapped[0], stride)
+	center := (exterior.MinX + exterior.MaxX) / 2
+	for _, ring := range unwrapped[1:] {
+		if len(ring) == 0 {
+			continue
+		}
+
+		shift := 360 * math.Round((center-ring[0])/360)
+		for idx := 0; idx < len(ring); idx += stride {
+			ring[idx] += shift
+		}
+	}
+
+	return unwrapped, true
+}
+
+// unwrapRing returns a copy of ring where every edge takes the shortest way
+// around the globe. Edges between two vertices on the antimeridian run along
+// it and keep their extent, as do edges of exactly 180 degrees, so that rings
+// that touch the antimeridian or span the whole globe are not collapsed. ok is
+// false if the resulting ring does not close.
+func unwrapRing(ring []float64, stride int) ([]float64, bool) {
+	unwrapped := slices.Clone(ring)
+	for idx := stride; idx < len(unwrapped); idx += stride {
+		delta := ring[idx] - ring[idx-stride]
+		onSeam := math.Abs(ring[idx]) == 180 && math.Abs(ring[idx-stride]) == 180
+		if !onSeam && math.Abs(delta) > 180 {
+			delta -= 360 * math.Round(delta/360)
+		}
+		unwrapped[idx] = unwrapped[idx-stride] + delta
+	}
+
+	if len(unwrapped) == 0 {
+		return unwrapped, true
+	}
+
+	last := lastCoord(unwrapped, stride)
+	if math.Abs(unwrapped[0]-last[0]) >= 1e-9 {
+		return unwrapped, false
+	}
+
+	// the sum of the deltas can be off by a rounding error, the planar graph
+	// needs the ring to be closed exactly
+	last[0] = unwrapped[0]
+
+	return unwrapped, true
+}
+
+// wrapLongitudes brings the longitudes of ring back into [-180, 180].
+// Longitudes that are only a rounding error beyond ±180 are put on it rather
+// than being wrapped to the other side of the antimeridian.
+func wrapLongitudes(ring []float64, stride int) {
+	for idx := 0; idx < len(ring); idx += stride {
+		switch lon := ring[idx]; {
+		case lon >= -180 && lon <= 180:
+		case math.Abs(math.Abs(lon)-180) < 1e-9:
+			ring[idx] = math.Copysign(180, lon)
+		default:
+			ring[idx] = mod(lon+180, 360) - 180
+		}
+	}
+}
+
+// planarGraph is the graph formed by a set of rings once they have been split
+// at every point where they touch or cross each other
+type planarGraph struct {
+	stride int
+	// nodes holds the full coordinate of every node
+	nodes [][]float64
+	// nodeIDs maps the x and y of a node to its index
+	nodeIDs map[[2]float64]int
+	// edges holds the number of times each edge, keyed by its nodes with the
+	// lowest index first, appears in the rings
+	edges map[[2]int]int
+}
+
+func newPlanarGraph(stride int) *planarGraph {
+	return &planarGraph{
+		stride:  stride,
+		nodeIDs: make(map[[2]float64]int),
+		edges:   make(map[[2]int]int),
+	}
+}
+
+// split is a point along an edge at which the edge is split
+type split struct {
+	t     float64
+	coord []float64
+}
+
+// rawEdge is an edge of the input rings along with the points where it is
+// split
+type rawEdge struct {
+	a, b   []float64
+	box    bbox
+	splits []split
+	// ring and vertex locate the start of the edge in the input
+	ring, vertex int
+}
+
+func newRawEdge(a, b []float64, ring, vertex int) *rawEdge {
+	return &rawEdge{
+		a:      a,
+		b:      b,
+		box:    bbox{MinX: min(a[0], b[0]), MinY: min(a[1], b[1]), MaxX: max(a[0], b[0]), MaxY: max(a[1], b[1])},
+		ring:   ring,
+		vertex: vertex,
+	}
+}
+
+// addRings splits the edges of rings wherever they intersect and adds the
+// pieces to the graph
+func (g *planarGraph) addRings(rings [][]float64) {
+	edges := make([]*rawEdge, 0)
+	for ringIdx, ring := range rings {
+		for idx := g.stride; idx < len(ring); idx += g.stride {
+			a, b := ring[idx-g.stride:idx], ring[idx:idx+g.stride]
+			if !sameXY(a, b) {
+				edges = append(edges, newRawEdge(a, b, ringIdx, idx/g.stride-1))
+			}
+		}
+	}
+
+	sweepEdges(edges, g.intersect)
+
+	for _, e := range edges {
+		slices.SortFunc(e.splits, func(s1, s2 split) int {
+			return cmpFloat(s1.t, s2.t)
+		})
+
+		prev := g.node(e.a)
+		for _, s := range e.splits {
+			node := g.node(s.coord)
+			g.addEdge(prev, node)
+			prev = node
+		}
+
+		g.addEdge(prev, g.node(e.b))
+	}
+}
+
+// sweepEdges calls fn for every pair of edges whose bounding boxes overlap.
+// The edges are sorted from west to east so that only edges with overlapping
+// longitudes are compared.
+func sweepEdges(edges []*rawEdge, fn func(e1, e2 *rawEdge)) {
+	slices.SortFunc(edges, func(e1, e2 *rawEdge) int {
+		return cmpFloat(e1.box.MinX, e2.box.MinX)
+	})
+
+	for ii, e1 := range edges {
+		for _, e2 := range edges[ii+1:] {
+			if e2.box.MinX > e1.box.MaxX {
+				break
+			}
+
+			if e2.box.MinY <= e1.box.MaxY && e1.box.MinY <= e2.box.MaxY {
+				fn(e1, e2)
+			}
+		}
+	}
+}
+
+// intersect records the points where e1 and e2 touch or cross as splits
+func (g *planarGraph) intersect(e1, e2 *rawEdge) {
+	rx, ry := e1.b[0]-e1.a[0], e1.b[1]-e1.a[1]
+	sx, sy := e2.b[0]-e2.a[0], e2.b[1]-e2.a[1]
+	qx, qy := e2.a[0]-e1.a[0], e2.a[1]-e1.a[1]
+	denom := rx*sy - ry*sx
+
+	if denom == 0 {
+		if qx*ry-qy*rx != 0 {
+			// parallel
+			return
+		}
+
+		// collinear, each edge is split at the ends of the other that fall
+		// within it
+		for _, end := range [][]float64{e2.a, e2.b} {
+			if t := project(e1, end); t > intersectionTolerance && t < 1-intersectionTolerance {
+				e1.splits = append(e1.splits, split{t: t, coord: end})
+			}
+		}
+
+		for _, end := range [][]float64{e1.a, e1.b} {
+			if u := project(e2, end); u > intersectionTolerance && u < 1-intersectionTolerance {
+				e2.splits = append(e2.splits, split{t: u, coord: end})
+			}
+		}
+
+		return
+	}
+
+	t := (qx*sy - qy*sx) / denom
+	u := (qx*ry - qy*rx) / denom
+	if t < -intersectionTolerance || t > 1+intersectionTolerance || u < -intersectionTolerance || u > 1+intersectionTolerance {
+		return
+	}
+
+	tInside := t > intersectionTolerance && t < 1-intersectionTolerance
+	uInside := u > intersectionTolerance && u < 1-intersectionTolerance
+
+	switch {
+	case tInside && uInside:
+		// a proper crossing, both edges are split at the same point
+		coord := interpolateCoord(e1.a, e1.b, t)
+		e1.splits = append(e1.splits, split{t: t, coord: coord})
+		e2.splits = append(e2.splits, split{t: u, coord: coord})
+	case tInside:
+		// an end of e2 touches e1
+		end := e2.a
+		if u > 0.5 {
+			end = e2.b
+		}
+		e1.splits = append(e1.splits, split{t: t, coord: end})
+	case uInside:
+		// an end of e1 touches e2
+		end := e1.a
+		if t > 0.5 {
+			end = e1.b
+		}
+		e2.splits = append(e2.splits, split{t: u, coord: end})
+	}
+}
+
+// project returns how far along e the projection of pt falls, as a fraction
+// of its length
+func project(e *rawEdge, pt []float64) float64 {
+	rx, ry := e.b[0]-e.a[0], e.b[1]-e.a[1]
+	return ((pt[0]-e.a[0])*rx + (pt[1]-e.a[1])*ry) / (rx*rx + ry*ry)
+}
+
+// interpolateCoord returns the coordinate the fraction t of the way from a to
+// b
+func interpolateCoord(a, b []float64, t float64) []float64 {
+	coord := make([]float64, len(a))
+	for idx := range a {
+		coord[idx] = a[idx] + t*(b[idx]-a[idx])
+	}
+
+	return coord
+}
+
+// node returns the index of the node at coord, adding it if it is new
+func (g *planarGraph) node(coord []float64) int {
+	key := [2]float64{coord[0], coord[1]}
+	if id, ok := g.nodeIDs[key]; ok {
+		return id
+	}
+
+	g.nodes = append(g.nodes, coord)
+	g.nodeIDs[key] = len(g.nodes) - 1
+
+	return len(g.nodes) - 1
+}
+
+func (g *planarGraph) addEdge(a, b int) {
+	if a == b {
+		return
+	}
+
+	g.edges[[2]int{min(a, b), max(a, b)}]++
+}
+
+// faces traces the boundary of the area covered by the graph under the
+// even-odd rule and returns it as rings of node indexes. Shells are
+// counter-clockwise and holes are clockwise.
+func (g *planarGraph) faces() [][]int {
+	// only edges that appear an odd number of times are part of the boundary
+	boundary := make([][2]int, 0, len(g.edges))
+	for nodes, count := range g.edges {
+		if count%2 == 1 {
+			boundary = append(boundary, nodes)
+		}
+	}
+
+	// map iteration order is random, sort the edges so the output is stable
+	slices.SortFunc(boundary, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+
+		return a[1] - b[1]
+	})
+
+	flatEdges := make([]float64, 0, 4*len(boundary))
+	for _, nodes := range boundary {
+		a, b := g.nodes[nodes[0]], g.nodes[nodes[1]]
+		flatEdges = append(flatEdges, a[0], a[1], b[0], b[1])
+	}
+	index := newEdgeIndex(flatEdges)
+
+	// orient every edge so that the covered area is on its left
+	for idx, nodes := range boundary {
+		a, b := g.nodes[nodes[0]], g.nodes[nodes[1]]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		eps := 1e-6
+		left := geom.Coord{(a[0]+b[0])/2 - dy*eps, (a[1]+b[1])/2 + dx*eps}
+		if !index.containsPoint(left) {
+			boundary[idx] = [2]int{nodes[1], nodes[0]}
+		}
+	}
+
+	// the edges around each node sorted counter-clockwise
+	type spoke struct {
+		angle float64
+		edge  int
+	}
+	spokes := make(map[int][]spoke)
+	for idx, nodes := range boundary {
+		for ii, node := range nodes {
+			other := g.nodes[nodes[1-ii]]
+			angle := math.Atan2(other[1]-g.nodes[node][1], other[0]-g.nodes[node][0])
+			spokes[node] = append(spokes[node], spoke{angle: angle, edge: idx})
+		}
+	}
+	for _, around := range spokes {
+		slices.SortFunc(around, func(a, b spoke) int {
+			return cmpFloat(a.angle, b.angle)
+		})
+	}
+
+	used := make([]bool, len(boundary))
+	faces := make([][]int, 0)
+	for start := range boundary {
+		if used[start] {
+			continue
+		}
+
+		face := []int{boundary[start][0]}
+		closed := false
+		for edge := start; !used[edge]; {
+			used[edge] = true
+			node := boundary[edge][1]
+			face = append(face, node)
+
+			if node == face[0] {
+				closed = true
+				break
+			}
+
+			// the next edge of the face is the first edge clockwise from the
+			// edge we arrived on that leaves the node
+			around := spokes[node]
+			pos := slices.IndexFunc(around, func(s spoke) bool { return s.edge == edge })
+			for step := 1; step <= len(around); step++ {
+				candidate := around[(pos-step+len(around))%len(around)].edge
+				if boundary[candidate][0] == node {
+					edge = candidate
+					break
+				}
+			}
+		}
+
+		if closed && len(face) >= 4 {
+			faces = append(faces, face)
+		}
+	}
+
+	return faces
+}
+
+// ring returns the flat coordinates of a ring of node indexes
+func (g *planarGraph) ring(nodes []int) []float64 {
+	ring := make([]float64, 0, len(nodes)*g.stride)
+	for _, node := range nodes {
+		ring = append(ring, g.nodes[node]...)
+	}
+
+	return ring
+}
+
+// pointNotOn returns a vertex of ring that is not a vertex of other
+func (g *planarGraph) pointNotOn(ring, other []int) (geom.Coord, bool) {
+	for _, node := range ring {
+		if !slices.Contains(other, node) {
+			return g.nodes[node][:2], true
+		}
+	}
+
+	return nil, false
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+// ringArea returns the signed area of the exterior of a polygon
+func ringArea(polygon *geom.Polygon) float64 {
+	coords := polygon.LinearRing(0).Coords()
+	area := 0.0
+	for idx := 1; idx < len(coords); idx++ {
+		area += coords[idx-1].X()*coords[idx].Y() - coords[idx].X()*coords[idx-1].Y()
+	}
+
+	return area / 2
+}
+
+var _ = Describe("Making valid", func() {
+	It("splits a bowtie into two triangles", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0}},
+		})
+
+		result, err := antimeridian.MakeValid(polygon)
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(2))
+		for idx := range multiPolygon.NumPolygons() {
+			Expect(multiPolygon.Polygon(idx).LinearRing(0).NumCoords()).To(Equal(4))
+			Expect(ringArea(multiPolygon.Polygon(idx))).To(BeNumerically("~", 25))
+		}
+	})
+
+	It("leaves valid polygons alone apart from their winding", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+			{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}},
+		})
+
+		result, err := antimeridian.MakeValid(polygon)
+		Expect(err).To(BeNil())
+
+		valid := result.(*geom.Polygon)
+		Expect(valid.NumLinearRings()).To(Equal(2))
+		Expect(ringArea(valid)).To(BeNumerically("~", 100))
+	})
+
+	It("does not treat a vertex shifted by 360 degrees as a self-intersection", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 0}, {-170, 0}, {190, 10}, {170, 10}, {170, 0}},
+		})
+
+		result, err := antimeridian.MakeValid(polygon)
+		Expect(err).To(BeNil())
+
+		valid := result.(*geom.Polygon)
+		Expect(valid.LinearRing(0).Coords()).To(ContainElement(geom.Coord{-170, 10}))
+		for _, coord := range valid.LinearRing(0).Coords() {
+			Expect(coord.X()).To(BeNumerically(">=", -180))
+			Expect(coord.X()).To(BeNumerically("<=", 180))
+		}
+	})
+
+	It("resolves a bowtie across the antimeridian before cutting", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 0}, {-170, 10}, {-170, 0}, {170, 10}, {170, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithMakeValid())
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(2))
+		area := 0.0
+		for idx := range multiPolygon.NumPolygons() {
+			Expect(ringArea(multiPolygon.Polygon(idx))).To(BeNumerically(">", 0))
+			area += ringArea(multiPolygon.Polygon(idx))
+		}
+		Expect(area).To(BeNumerically("~", 100))
+	})
+
+	DescribeTable("keeps polygons with a vertex on the antimeridian",
+		func(name string, seam float64) {
+			// the vertex on the antimeridian is written as seam, the area is
+			// that of the ring with it written as -180
+			coords := readInput(name).(*geom.Polygon).Coords()
+			shifted := geom.NewPolygon(geom.XY).MustSetCoords(coords).Coords()
+			for idx, coord := range coords[0] {
+				if coord[0] == 180 {
+					coord[0] = seam
+				}
+				if shifted[0][idx][0] > 179 {
+					shifted[0][idx][0] -= 360
+				}
+			}
+			polygon := geom.NewPolygon(geom.XY).MustSetCoords(coords)
+			expected := ringArea(geom.NewPolygon(geom.XY).MustSetCoords(shifted))
+			Expect(expected).NotTo(BeZero())
+
+			result, err := antimeridian.MakeValid(polygon)
+			Expect(err).To(BeNil())
+			Expect(ringArea(result.(*geom.Polygon))).To(BeNumerically("~", expected, 1e-9))
+
+			result, err = antimeridian.CutWithOptions(polygon, antimeridian.WithMakeValid())
+			Expect(err).To(BeNil())
+			Expect(polygonsOf(result)).NotTo(BeEmpty())
+		},
+		Entry("at 180", "point-on-antimeridian", 180.0),
+		Entry("at -180", "point-on-antimeridian", -180.0),
+		Entry("almost at 180", "almost-180", 180.0),
+	)
+
+	It("keeps a band around the whole globe", func() {
+		band := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-180, 40}, {180, 40}, {180, 50}, {-180, 50}, {-180, 40}},
+		})
+
+		result, err := antimeridian.MakeValid(band)
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.Polygon).Bounds()).To(Equal(band.Bounds()))
+		Expect(ringArea(result.(*geom.Polygon))).To(BeNumerically("~", 3600))
+
+		result, err = antimeridian.CutWithOptions(band, antimeridian.WithMakeValid())
+		Expect(err).To(BeNil())
+		Expect(polygonsOf(result)).NotTo(BeEmpty())
+	})
+
+	It("works on the output of Cut", func() {
+		result, err := antimeridian.Cut(readInput("complex-split"))
+		Expect(err).To(BeNil())
+
+		valid, err := antimeridian.MakeValid(result)
+		Expect(err).To(BeNil())
+		Expect(valid.(*geom.MultiPolygon).NumPolygons()).To(Equal(result.(*geom.MultiPolygon).NumPolygons()))
+	})
+})
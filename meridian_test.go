@@ -0,0 +1,101 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"errors"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Cutting at a meridian", func() {
+	It("cuts like Cut at the antimeridian", func() {
+		obj := readInput("complex-split")
+
+		expected, err := antimeridian.Cut(obj)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutAt(obj, 180)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+	})
+
+	It("cuts at another meridian with output west of it", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-40, 0}, {-20, 0}, {-20, 10}, {-40, 10}, {-40, 0}},
+		})
+
+		result, err := antimeridian.CutAt(polygon, -30)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{
+			-30, 10, -40, 10, -40, 0, -30, 0, -30, 10,
+			-390, 0, -380, 0, -380, 10, -390, 10, -390, 0,
+		}))
+	})
+
+	It("wraps polygons east of the meridian into range", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{100, 0}, {110, 0}, {110, 10}, {100, 10}, {100, 0}},
+		})
+
+		result, err := antimeridian.CutAt(polygon, 90)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{-260, 0, -250, 0, -250, 10, -260, 10, -260, 0}))
+	})
+
+	It("leaves polygons that do not cross the meridian whole", func() {
+		obj := readInput("split")
+
+		result, err := antimeridian.CutAt(obj, -30)
+		Expect(err).To(BeNil())
+		Expect(result).To(BeAssignableToTypeOf(&geom.Polygon{}))
+	})
+})
+
+var _ = Describe("Recentering", func() {
+	It("wraps longitudes around the central meridian", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-170, 0}, {-160, 0}, {-160, 10}, {150, 10}, {-170, 0}},
+		})
+
+		result, err := antimeridian.Recenter(polygon, 150)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{190, 0, 200, 0, 200, 10, 150, 10, 190, 0}))
+
+		// the input is left untouched
+		Expect(polygon.FlatCoords()[0]).To(Equal(-170.0))
+	})
+
+	It("recenters the output of CutAt for a Pacific centred map", func() {
+		cut, err := antimeridian.CutAt(readInput("split"), -30)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.Recenter(cut, 150)
+		Expect(err).To(BeNil())
+		for idx := 0; idx < len(result.FlatCoords()); idx += result.Stride() {
+			Expect(result.FlatCoords()[idx]).To(BeNumerically(">=", -30))
+			Expect(result.FlatCoords()[idx]).To(BeNumerically("<=", 330))
+		}
+	})
+
+	It("rejects other geometries", func() {
+		_, err := antimeridian.Recenter(geom.NewPointFlat(geom.XY, []float64{0, 0}), 150)
+		Expect(errors.Is(err, antimeridian.ErrUnsupportedType)).To(BeTrue())
+	})
+})
@@ -15,29 +15,140 @@
 
 package antimeridian
 
-import "github.com/twpayne/go-geom"
+import (
+	"errors"
 
-func cutMultiPolygon(multiPoly *geom.MultiPolygon, fixWindingArr ...bool) (*geom.MultiPolygon, error) {
-	fixWinding := true
-	if len(fixWindingArr) > 0 {
-		fixWinding = fixWindingArr[0]
+	"github.com/twpayne/go-geom"
+)
+
+// memberResult is the outcome of cutting a single member of a multi-polygon
+type memberResult struct {
+	polygons [][][]float64
+	asIs     bool
+	err      error
+	done     bool
+}
+
+func (c *cutter) cutMultiPolygon(multiPoly *geom.MultiPolygon) (*geom.MultiPolygon, error) {
+	layout := multiPoly.Layout()
+	flatCoords := multiPoly.FlatCoords()
+
+	members := make([][][]float64, multiPoly.NumPolygons())
+	offset := 0
+	for idx, ends := range multiPoly.Endss() {
+		members[idx] = splitRings(flatCoords, offset, ends)
+		if len(ends) > 0 {
+			offset = ends[len(ends)-1]
+		}
 	}
 
-	multiPolygon := geom.NewMultiPolygon(multiPoly.Layout())
+	// every member is wound like its own polygon of the input
+	targets := make([]winding, len(members))
+	for idx, member := range members {
+		targets[idx] = c.windingOf(layout, member)
+	}
+
+	results := c.cutMembers(layout, members)
+
+	polygons := make([][][]float64, 0, len(members))
+	windings := make([]winding, 0, len(members))
+	errs := make([]error, 0)
+	allAsIs := true
+	for idx, result := range results {
+		if result.err != nil {
+			if !c.opts.partial {
+				return nil, result.err
+			}
 
-	for idx := range multiPoly.NumPolygons() {
-		poly := multiPoly.Polygon(idx)
-		fixedPolys, err := fixPolygonToList(poly, fixWinding)
-		if err != nil {
-			return nil, err
+			errs = append(errs, result.err)
+			allAsIs = false
+			continue
 		}
 
-		for _, polygon := range fixedPolys {
-			if err := multiPolygon.Push(polygon); err != nil {
-				return nil, err
+		allAsIs = allAsIs && result.asIs
+		cut := result.polygons
+		if c.opts.clip {
+			var clipped bool
+			cut, clipped = c.clipLatitudes(cut, layout.Stride())
+			allAsIs = allAsIs && !clipped
+		}
+
+		polygons = append(polygons, cut...)
+		for range cut {
+			windings = append(windings, targets[idx])
+		}
+	}
+
+	if !allAsIs {
+		c.insetSeam(polygons, members, layout.Stride())
+	}
+
+	// members that are used as they are share their rings with the input, so
+	// with inPlace they are reoriented and rotated in the input itself
+	changed := false
+	for idx := range polygons {
+		changed = c.orient(layout, polygons[idx:idx+1], windings[idx]) || changed
+	}
+	changed = c.rotateRings(polygons, layout.Stride()) || changed
+	if changed && !c.opts.inPlace {
+		allAsIs = false
+	}
+
+	if c.sortPolygons(polygons, layout.Stride()) {
+		allAsIs = false
+	}
+
+	if allAsIs && c.opts.inPlace {
+		return multiPoly, nil
+	}
+
+	flatCoords, endss := flattenPolygons(polygons)
+	return geom.NewMultiPolygonFlat(layout, flatCoords, endss), errors.Join(errs...)
+}
+
+// cutMembers cuts each member polygon, spreading the work over several
+// goroutines if the cutter has been configured to. When cutting sequentially
+// it stops at the first member that fails, unless partial results have been
+// asked for.
+func (c *cutter) cutMembers(layout geom.Layout, members [][][]float64) []memberResult {
+	results := make([]memberResult, len(members))
+
+	workers := resolveWorkers(c.opts.memberWorkers, len(members))
+	if c.opts.memberWorkers == 0 || workers == 1 {
+		for idx, rings := range members {
+			c.polygon = idx
+			result := &results[idx]
+			result.polygons, result.asIs, result.err = c.fixPolygonToList(layout, rings)
+			if result.err != nil && !c.opts.partial {
+				return results[:idx+1]
+			}
+		}
+
+		return results
+	}
+
+	// every worker needs its own scratch space
+	cutters := make([]*cutter, workers)
+	cutters[0] = c
+	for idx := 1; idx < workers; idx++ {
+		cutters[idx] = newCutter(c.ctx, c.opts)
+		cutters[idx].frame = c.frame
+	}
+
+	err := forEach(c.ctx, len(members), workers, func(worker, idx int) {
+		cutters[worker].polygon = idx
+		result := &results[idx]
+		result.polygons, result.asIs, result.err = cutters[worker].fixPolygonToList(layout, members[idx])
+		result.done = true
+	})
+	if err != nil {
+		// the members that were never started have no error of their own
+		for idx := range results {
+			if !results[idx].done {
+				results[idx].err = &Error{Polygon: idx, Ring: -1, Vertex: -1, Reason: "cancelled before starting", Err: err}
 			}
 		}
 	}
 
-	return multiPolygon, nil
+	return results
 }
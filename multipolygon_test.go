@@ -16,6 +16,8 @@
 package antimeridian_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -60,3 +62,47 @@ var _ = DescribeTable("Various Multi-polygons",
 	Entry("no antimeridian", "multi-no-antimeridian"),
 	Entry("multi split", "multi-split"),
 )
+
+var _ = Describe("Partial results", func() {
+	It("returns an error for every failed member", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		multiPolygon := readInput("multi-split")
+		result, err := antimeridian.CutContext(ctx, multiPolygon, antimeridian.WithPartialResults(true))
+		Expect(result).NotTo(BeNil())
+		Expect(err).To(MatchError(context.Canceled))
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		Expect(ok).To(BeTrue())
+		Expect(joined.Unwrap()).To(HaveLen(multiPolygon.(*geom.MultiPolygon).NumPolygons()))
+
+		for idx, memberErr := range joined.Unwrap() {
+			var cutErr *antimeridian.Error
+			Expect(errors.As(memberErr, &cutErr)).To(BeTrue())
+			Expect(cutErr.Polygon).To(Equal(idx))
+		}
+	})
+
+	It("keeps the members that were cut", func() {
+		multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{170, -10}, {-170, -10}, {-170, 10}, {170, 10}, {170, -10}}},
+			{{{0, 0}, {1, 0}, {1, 95}, {0, 0}}},
+			{{{10, 0}, {11, 0}, {11, 1}, {10, 0}}},
+		})
+
+		result, err := antimeridian.CutWithOptions(multiPolygon, antimeridian.WithPartialResults(true))
+		Expect(errors.Is(err, antimeridian.ErrLatitudeOutOfRange)).To(BeTrue())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(3))
+
+		var cutErr *antimeridian.Error
+		Expect(errors.As(err, &cutErr)).To(BeTrue())
+		Expect(cutErr.Polygon).To(Equal(1))
+	})
+
+	It("returns no error when every member succeeds", func() {
+		result, err := antimeridian.CutWithOptions(readInput("multi-split"), antimeridian.WithPartialResults(true))
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(BeNumerically(">", 0))
+	})
+})
@@ -0,0 +1,302 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+// Option configures how geometries are cut
+type Option func(*options)
+
+type options struct {
+	fixWinding bool
+	inPlace    bool
+	validate   bool
+	repair     bool
+	minArea    float64
+	makeValid  bool
+
+	reflectPoles bool
+
+	repairSpikes bool
+	reportSpike  func(Spike)
+
+	axisOrder        AxisOrder
+	restoreAxisOrder bool
+
+	lonConvention       LonConvention
+	outputLonConvention LonConvention
+	meridian            float64
+	hasMeridian         bool
+	coordinateSystem    CoordinateSystem
+
+	latitudeLimit float64
+	clip          bool
+	densifyStep   float64
+
+	densifyMax  float64
+	densifyMode DensifyMode
+
+	seamInset   float64
+	mergeSeam   bool
+	orientation Orientation
+
+	sortPieces     bool
+	canonicalStart bool
+
+	memberWorkers int
+	partial       bool
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		fixWinding: true,
+		validate:   true,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithFixWinding controls whether improperly wound geometries are fixed. It is
+// enabled by default; however, there are instances where the polygon may be
+// correctly wound but antimeridian cannot determine this to be so; for
+// example, when the polygon extends over both the north and south pole.
+func WithFixWinding(fixWinding bool) Option {
+	return func(o *options) {
+		o.fixWinding = fixWinding
+	}
+}
+
+// WithInPlace allows the coordinates of the input geometry to be modified
+// instead of copied. Geometries that do not need to be cut are returned as-is
+// with their winding fixed, which avoids allocating a new geometry. The input
+// geometry must not be used after it has been cut in place.
+func WithInPlace(inPlace bool) Option {
+	return func(o *options) {
+		o.inPlace = inPlace
+	}
+}
+
+// WithValidation controls whether geometries are checked with Validate before
+// they are cut. It is enabled by default. Turning it off skips the check for
+// input that is known to be valid, but invalid input may then cause a panic or
+// produce incorrect output.
+func WithValidation(validate bool) Option {
+	return func(o *options) {
+		o.validate = validate
+	}
+}
+
+// WithRepair cleans up common problems in the input and the output of cutting.
+// Open rings in the input are closed, consecutive duplicate coordinates are
+// collapsed and interior rings left with too few coordinates are dropped. In
+// the output consecutive duplicate coordinates, which appear where a ring runs
+// along the antimeridian, are collapsed and polygons and interior rings with
+// an area of no more than minArea are dropped. minArea is in the square units
+// of the coordinate system: square degrees for Geographic and square metres
+// for WebMercator.
+func WithRepair(minArea float64) Option {
+	return func(o *options) {
+		o.repair = true
+		o.minArea = minArea
+	}
+}
+
+// WithPoleReflection brings latitudes beyond ±90, such as those of a path
+// that carries on over a pole, back into range before the geometry is
+// validated and cut. A latitude of 91 becomes 89 and the longitude moves by
+// 180 degrees to the other side of the pole. Edges that run over a pole are
+// split there, going up to the pole and coming back down on the other side.
+func WithPoleReflection(reflect bool) Option {
+	return func(o *options) {
+		o.reflectPoles = reflect
+	}
+}
+
+// WithMakeValid resolves self-intersections in each polygon with MakeValid
+// before it is cut.
+func WithMakeValid() Option {
+	return func(o *options) {
+		o.makeValid = true
+	}
+}
+
+// WithSpikeRepair moves spikes, isolated vertices on the wrong side of the
+// antimeridian, back next to their neighbours before cutting. report, if not
+// nil, is called with every spike that is repaired, with the longitudes and
+// vertex indexes of the input. When members of a
+// multi-polygon are cut concurrently report may be called from several
+// goroutines at once. See FindSpikes for detecting spikes without repairing
+// them.
+func WithSpikeRepair(report func(Spike)) Option {
+	return func(o *options) {
+		o.repairSpikes = true
+		o.reportSpike = report
+	}
+}
+
+// WithAxisOrder sets the axis order of the input. Coordinates of LatLon input
+// are swapped before cutting, and the output is LonLat unless
+// WithRestoreAxisOrder is used. The default is LonLat.
+func WithAxisOrder(order AxisOrder) Option {
+	return func(o *options) {
+		o.axisOrder = order
+	}
+}
+
+// WithRestoreAxisOrder swaps the coordinates of the output back to the axis
+// order of the input when the input was LatLon.
+func WithRestoreAxisOrder(restore bool) Option {
+	return func(o *options) {
+		o.restoreAxisOrder = restore
+	}
+}
+
+// WithLonConvention sets the longitude convention of the input. The default is
+// Lon180. With Lon360 input a longitude of 180 is on whichever side of the
+// antimeridian the previous vertex is.
+func WithLonConvention(convention LonConvention) Option {
+	return func(o *options) {
+		o.lonConvention = convention
+	}
+}
+
+// WithOutputLonConvention sets the longitude convention of the output. With
+// Lon180, the default, geometries are cut at the antimeridian as GeoJSON
+// expects. With Lon360 they are instead cut at the prime meridian so that the
+// output fits in [0, 360]. AutoLon keeps the convention of the input.
+// Longitudes that are moved between conventions are rounded to 10 decimal
+// places.
+func WithOutputLonConvention(convention LonConvention) Option {
+	return func(o *options) {
+		o.outputLonConvention = convention
+	}
+}
+
+// WithCoordinateSystem sets the coordinate system of the input and output.
+// The default is Geographic. With WebMercator geometries are cut at
+// x = ±20037508.34 and validation checks y against the latitude limit of the
+// projection rather than ±90. CutAt takes its meridian in the units of the
+// coordinate system.
+func WithCoordinateSystem(cs CoordinateSystem) Option {
+	return func(o *options) {
+		o.coordinateSystem = cs
+	}
+}
+
+// WithLatitudeLimit clips the output of cutting to latitudes between -limit
+// and limit, in degrees, by intersecting each polygon with the band. Pieces
+// that cover a pole otherwise have edges at ±90, which can't be projected to
+// Web Mercator; MercatorLatitudeLimit is the limit to use for it.
+func WithLatitudeLimit(limit float64) Option {
+	return func(o *options) {
+		o.latitudeLimit = limit
+		o.clip = true
+	}
+}
+
+// WithSeamDensification adds points to the edges that cutting creates along
+// the antimeridian and across the poles, and to those created by
+// WithLatitudeLimit, so that none is longer than step degrees. Such edges
+// otherwise have only two points and come out as straight chords when
+// reprojected, for example to polar stereographic. Edges from the input are
+// left alone.
+func WithSeamDensification(step float64) Option {
+	return func(o *options) {
+		o.densifyStep = step
+	}
+}
+
+// WithDensify densifies polygons with Densify before cutting them, so that
+// long edges are cut where their great circles or rhumb lines cross the
+// antimeridian rather than where their straight lines do. maxSegment is in
+// degrees of arc, whatever the coordinate system.
+func WithDensify(maxSegment float64, mode DensifyMode) Option {
+	return func(o *options) {
+		o.densifyMax = maxSegment
+		o.densifyMode = mode
+	}
+}
+
+// WithSeamInset moves the vertices that cutting adds on the antimeridian
+// inward by epsilon, in the units of the coordinate system, so that 180
+// becomes 180-epsilon. Some renderers and databases misbehave with vertices at
+// exactly ±180. Vertices on the antimeridian at the latitude of a vertex of the
+// input that is on it stay where they are, as do vertices at the poles, so the
+// edges along the poles do not cross the antimeridian. Geometries that do not
+// need cutting are returned unchanged.
+func WithSeamInset(epsilon float64) Option {
+	return func(o *options) {
+		o.seamInset = epsilon
+	}
+}
+
+// WithSeamMerge makes Replicate merge pieces that meet across the antimeridian
+// with their neighbours, so that the copies of a shape that crosses it render
+// without a seam.
+func WithSeamMerge() Option {
+	return func(o *options) {
+		o.mergeSeam = true
+	}
+}
+
+// WithOrientation sets the winding of the rings of the output of cutting. The
+// default is RFC7946.
+func WithOrientation(orientation Orientation) Option {
+	return func(o *options) {
+		o.orientation = orientation
+	}
+}
+
+// WithSortedPieces sorts the polygons of the output of cutting from west to
+// east and then from south to north, by the bounding boxes of their exterior
+// rings, rather than in the order that cutting produces them.
+func WithSortedPieces() Option {
+	return func(o *options) {
+		o.sortPieces = true
+	}
+}
+
+// WithCanonicalStart rotates every ring of the output of cutting to start at
+// its vertex with the smallest longitude and then the smallest latitude, so
+// that the same shape always gives the same coordinates. Polygons that do not
+// need cutting are rotated too.
+func WithCanonicalStart() Option {
+	return func(o *options) {
+		o.canonicalStart = true
+	}
+}
+
+// WithMemberWorkers cuts the members of multi-polygons using up to workers
+// goroutines. This is worthwhile for multi-polygons with many large members.
+// If workers is less than zero GOMAXPROCS workers are used. By default members
+// are cut one at a time.
+func WithMemberWorkers(workers int) Option {
+	return func(o *options) {
+		o.memberWorkers = workers
+	}
+}
+
+// WithPartialResults makes cutting a multi-polygon carry on past members that
+// fail. The members that were cut successfully are returned along with an
+// error joining the errors of every failed member, each of which is an *Error
+// holding the index of the member.
+func WithPartialResults(partial bool) Option {
+	return func(o *options) {
+		o.partial = partial
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+// Orientation is the winding of the rings in the output of cutting
+type Orientation int
+
+const (
+	// RFC7946 winds exterior rings counter-clockwise and interior rings
+	// clockwise, as required by GeoJSON. This is the default. With
+	// WithFixWinding(false) the rings of polygons that do not need cutting
+	// keep their winding.
+	RFC7946 Orientation = iota
+	// Clockwise winds exterior rings clockwise and interior rings
+	// counter-clockwise, as in OGC simple features and Esri shapefiles
+	Clockwise
+	// PreserveOrientation winds the rings like those of the input. Each
+	// polygon of the output takes the winding of its exterior ring from the
+	// exterior ring of the polygon of the input it came from, and the winding
+	// of its interior rings from the first interior ring of that polygon, or
+	// the opposite of the exterior if there is none.
+	PreserveOrientation
+)
+
+// winding is the winding wanted for the rings of the output
+type winding struct {
+	exteriorCCW, interiorCCW bool
+	// apply is false if the output of cutting can be used as it is
+	apply bool
+}
+
+// windingOf returns the winding of the output for a polygon with the given
+// rings. It has to be called before the rings are cut, as cutting in place can
+// change their winding.
+func (c *cutter) windingOf(layout geom.Layout, rings [][]float64) winding {
+	switch c.opts.orientation {
+	case Clockwise:
+		return winding{exteriorCCW: false, interiorCCW: true, apply: true}
+	case PreserveOrientation:
+		if len(rings) == 0 {
+			return winding{}
+		}
+
+		w := winding{exteriorCCW: isUnwrappedCCW(layout, rings[0]), apply: true}
+		w.interiorCCW = !w.exteriorCCW
+		if len(rings) > 1 {
+			w.interiorCCW = isUnwrappedCCW(layout, rings[1])
+		}
+
+		return w
+	default:
+		return winding{}
+	}
+}
+
+// orient winds the rings of polygons as given by w. Rings are copied before
+// they are reversed unless the cutter is allowed to modify its input. changed
+// reports if any ring was reversed.
+func (c *cutter) orient(layout geom.Layout, polygons [][][]float64, w winding) (changed bool) {
+	if !w.apply {
+		return false
+	}
+
+	for _, polygon := range polygons {
+		for idx, ring := range polygon {
+			ccw := w.interiorCCW
+			if idx == 0 {
+				ccw = w.exteriorCCW
+			}
+
+			if xy.IsRingCounterClockwise(layout, ring) != ccw {
+				polygon[idx] = c.reverse(ring, layout.Stride())
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// isUnwrappedCCW reports if ring is wound counter-clockwise once it has been
+// unwrapped, so that rings crossing the antimeridian are measured correctly
+func isUnwrappedCCW(layout geom.Layout, ring []float64) bool {
+	if unwrapped, ok := unwrapRing(ring, layout.Stride()); ok {
+		ring = unwrapped
+	}
+
+	return xy.IsRingCounterClockwise(layout, ring)
+}
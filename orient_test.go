@@ -0,0 +1,182 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"slices"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+// windings returns if each exterior and each interior ring of obj is wound
+// counter-clockwise
+func windings(obj geom.T) (exteriors, interiors []bool) {
+	for _, polygon := range polygonsOf(obj) {
+		for idx := range polygon.NumLinearRings() {
+			ccw := xy.IsRingCounterClockwise(polygon.Layout(), polygon.LinearRing(idx).FlatCoords())
+			if idx == 0 {
+				exteriors = append(exteriors, ccw)
+			} else {
+				interiors = append(interiors, ccw)
+			}
+		}
+	}
+
+	return exteriors, interiors
+}
+
+// reversed returns a copy of polygon with every ring reversed
+func reversed(polygon *geom.Polygon) *geom.Polygon {
+	coords := polygon.Coords()
+	for _, ring := range coords {
+		slices.Reverse(ring)
+	}
+
+	return geom.NewPolygon(polygon.Layout()).MustSetCoords(coords)
+}
+
+var _ = Describe("Orientation", func() {
+	DescribeTable("winds the output clockwise",
+		func(name string) {
+			expected, err := antimeridian.Cut(readInput(name))
+			Expect(err).To(BeNil())
+
+			result, err := antimeridian.CutWithOptions(readInput(name), antimeridian.WithOrientation(antimeridian.Clockwise))
+			Expect(err).To(BeNil())
+			Expect(result.Bounds()).To(Equal(expected.Bounds()))
+
+			exteriors, interiors := windings(result)
+			Expect(exteriors).NotTo(BeEmpty())
+			Expect(exteriors).To(HaveEach(false))
+			if len(interiors) > 0 {
+				Expect(interiors).To(HaveEach(true))
+			}
+		},
+		Entry("split", "split"),
+		Entry("one hole", "one-hole"),
+		Entry("two holes", "two-holes"),
+		Entry("simple with ccw hole", "simple-with-ccw-hole"),
+		Entry("multi split", "multi-split"),
+		Entry("north pole", "north-pole"),
+		Entry("multi no antimeridian", "multi-no-antimeridian"),
+	)
+
+	It("does not modify the input of polygons that are not cut", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}},
+		})
+		original := polygon.Clone()
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithOrientation(antimeridian.Clockwise))
+		Expect(err).To(BeNil())
+		Expect(polygon.FlatCoords()).To(Equal(original.FlatCoords()))
+		Expect(result.(*geom.Polygon).Coords()).To(Equal(reversed(original).Coords()))
+	})
+
+	It("reorients polygons that are not cut in place", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}},
+		})
+		original := polygon.Clone()
+
+		result, err := antimeridian.CutWithOptions(polygon,
+			antimeridian.WithOrientation(antimeridian.Clockwise),
+			antimeridian.WithInPlace(true))
+		Expect(err).To(BeNil())
+		Expect(result).To(BeIdenticalTo(polygon))
+		Expect(polygon.Coords()).To(Equal(reversed(original).Coords()))
+	})
+
+	It("preserves the winding of the input", func() {
+		input := reversed(geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 40}, {-170, 40}, {-170, 60}, {170, 60}, {170, 40}},
+			{{172, 45}, {172, 55}, {175, 55}, {175, 45}, {172, 45}},
+		}))
+
+		result, err := antimeridian.CutWithOptions(input, antimeridian.WithOrientation(antimeridian.PreserveOrientation))
+		Expect(err).To(BeNil())
+
+		exteriors, interiors := windings(result)
+		Expect(exteriors).To(HaveLen(2))
+		Expect(exteriors).To(HaveEach(false))
+		Expect(interiors).To(HaveLen(1))
+		Expect(interiors).To(HaveEach(true))
+	})
+
+	Describe("preserving the winding of each member of a multi-polygon", func() {
+		// the member that crosses the antimeridian is clockwise, the other
+		// counter-clockwise
+		mixed := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{170, 40}, {170, 60}, {-170, 60}, {-170, 40}, {170, 40}}},
+			{{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}}},
+		})
+		preserve := antimeridian.WithOrientation(antimeridian.PreserveOrientation)
+
+		expectMemberWindings := func(obj geom.T) {
+			for _, polygon := range polygonsOf(obj) {
+				ccw := xy.IsRingCounterClockwise(polygon.Layout(), polygon.LinearRing(0).FlatCoords())
+				Expect(ccw).To(Equal(polygon.Bounds().Max(1) <= 10))
+			}
+		}
+
+		It("winds the pieces of a member like the member", func() {
+			result, err := antimeridian.CutWithOptions(mixed, preserve)
+			Expect(err).To(BeNil())
+			Expect(polygonsOf(result)).To(HaveLen(3))
+			expectMemberWindings(result)
+		})
+
+		It("winds merged copies like the member", func() {
+			result, err := antimeridian.Replicate(mixed, 1, preserve, antimeridian.WithSeamMerge())
+			Expect(err).To(BeNil())
+			expectMemberWindings(result)
+		})
+	})
+
+	It("matches RFC 7946 when preserving RFC 7946 input", func() {
+		expected, err := antimeridian.Cut(readInput("one-hole"))
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.CutWithOptions(readInput("one-hole"), antimeridian.WithOrientation(antimeridian.PreserveOrientation))
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+	})
+
+	It("winds flat output clockwise", func() {
+		polygon := readInput("split").(*geom.Polygon)
+
+		flatCoords, endss, err := antimeridian.CutFlat(polygon.Layout(), polygon.FlatCoords(), polygon.Ends(),
+			antimeridian.WithOrientation(antimeridian.Clockwise))
+		Expect(err).To(BeNil())
+
+		exteriors, _ := windings(geom.NewMultiPolygonFlat(polygon.Layout(), flatCoords, endss))
+		Expect(exteriors).To(Equal([]bool{false, false}))
+	})
+
+	It("winds merged copies like the rest of the output", func() {
+		result, err := antimeridian.Replicate(readInput("split"), 1,
+			antimeridian.WithOrientation(antimeridian.Clockwise),
+			antimeridian.WithSeamMerge())
+		Expect(err).To(BeNil())
+
+		exteriors, _ := windings(result)
+		Expect(exteriors).To(HaveEach(false))
+	})
+})
@@ -16,6 +16,8 @@
 package antimeridian
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"slices"
 
@@ -28,209 +30,461 @@ type edge struct {
 	Val   float64
 }
 
-func cutPolygon(poly *geom.Polygon, fixWindingArr ...bool) (geom.T, error) {
-	fixWinding := true
-	if len(fixWindingArr) > 0 {
-		fixWinding = fixWindingArr[0]
+// crossing is the direction in which an edge crosses the antimeridian
+type crossing int
+
+const (
+	noCrossing crossing = iota
+	leftCrossing
+	rightCrossing
+)
+
+// cutter holds the options and scratch space used while cutting geometries.
+// A cutter must not be used concurrently.
+type cutter struct {
+	ctx     context.Context
+	opts    *options
+	scratch []float64
+
+	// frame is the working frame of the geometry being cut
+	frame frame
+
+	// polygon is the index of the multi-polygon member being cut
+	polygon int
+}
+
+func newCutter(ctx context.Context, opts *options) *cutter {
+	return &cutter{ctx: ctx, opts: opts}
+}
+
+// cancelled returns an error wrapping ctx.Err() if the cutter's context is
+// done. The error reports that done of total items of the kind given by what
+// had been processed for the current polygon, and the ring being processed if
+// there is one.
+func (c *cutter) cancelled(ring, done, total int, what string) error {
+	if err := c.ctx.Err(); err != nil {
+		return c.newError(ring, -1, err, fmt.Sprintf("cancelled after %d of %d %s", done, total, what))
+	}
+
+	return nil
+}
+
+// cutGeometry divides a geometry in the working frame at the antimeridian
+// and the poles
+func (c *cutter) cutGeometry(obj geom.T) (geom.T, error) {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		return c.cutPolygon(geometry)
+	case *geom.MultiPolygon:
+		return c.cutMultiPolygon(geometry)
+	default:
+		// unsupported type
+		return obj, ErrUnsupportedType
 	}
+}
 
-	polygons, err := fixPolygonToList(poly, fixWinding)
+func (c *cutter) cutPolygon(poly *geom.Polygon) (geom.T, error) {
+	rings := splitRings(poly.FlatCoords(), 0, poly.Ends())
+	target := c.windingOf(poly.Layout(), rings)
+	polygons, asIs, err := c.fixPolygonToList(poly.Layout(), rings)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(polygons) == 1 {
-		polygon := polygons[0]
+	if c.opts.clip {
+		var clipped bool
+		polygons, clipped = c.clipLatitudes(c.enclose(poly.Layout(), polygons), poly.Stride())
+		asIs = asIs && !clipped
+	}
 
-		if xy.IsRingCounterClockwise(polygon.Layout(), polygon.FlatCoords()) {
-			return polygon, nil
+	if asIs && (len(polygons) == 0 || xy.IsRingCounterClockwise(poly.Layout(), polygons[0][0])) {
+		changed := c.orient(poly.Layout(), polygons, target)
+		changed = c.rotateRings(polygons, poly.Stride()) || changed
+		if !changed || c.opts.inPlace {
+			return poly, nil
 		}
+	} else {
+		polygons = c.enclose(poly.Layout(), polygons)
+		c.insetSeam(polygons, [][][]float64{rings}, poly.Stride())
+		c.orient(poly.Layout(), polygons, target)
+		c.rotateRings(polygons, poly.Stride())
+		c.sortPolygons(polygons, poly.Stride())
+	}
 
-		return geom.NewPolygon(polygon.Layout()).MustSetCoords(
-			[][]geom.Coord{
-				{{-180, 90}, {-180, -90}, {180, -90}, {180, 90}},
-				polygon.LinearRing(0).Coords(),
-			},
-		), nil
+	flatCoords, endss := flattenPolygons(polygons)
+	if len(endss) == 1 {
+		return geom.NewPolygonFlat(poly.Layout(), flatCoords, endss[0]), nil
 	}
 
 	// more than one polygon was returned which means we should return a
 	// multipolygon
+	return geom.NewMultiPolygonFlat(poly.Layout(), flatCoords, endss), nil
+}
 
-	multiPolygon := geom.NewMultiPolygon(poly.Layout())
-	for _, polygon := range polygons {
-		if err := multiPolygon.Push(polygon); err != nil {
-			return nil, err
+// cutFlatWork divides a polygon in the working frame at the antimeridian and
+// the poles
+func (c *cutter) cutFlatWork(layout geom.Layout, flatCoords []float64, ends []int) ([]float64, [][]int, error) {
+	rings := splitRings(flatCoords, 0, ends)
+	target := c.windingOf(layout, rings)
+	polygons, asIs, err := c.fixPolygonToList(layout, rings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.opts.clip {
+		var clipped bool
+		polygons, clipped = c.clipLatitudes(c.enclose(layout, polygons), layout.Stride())
+		asIs = asIs && !clipped
+	}
+
+	if asIs && (len(polygons) == 0 || xy.IsRingCounterClockwise(layout, polygons[0][0])) {
+		changed := c.orient(layout, polygons, target)
+		changed = c.rotateRings(polygons, layout.Stride()) || changed
+		if c.opts.inPlace {
+			return flatCoords, [][]int{ends}, nil
 		}
+		if !changed {
+			// the result must not share the caller's slices
+			return slices.Clone(flatCoords), [][]int{slices.Clone(ends)}, nil
+		}
+	} else {
+		polygons = c.enclose(layout, polygons)
+		c.insetSeam(polygons, [][][]float64{rings}, layout.Stride())
+		c.orient(layout, polygons, target)
+		c.rotateRings(polygons, layout.Stride())
+		c.sortPolygons(polygons, layout.Stride())
 	}
 
-	return multiPolygon, nil
+	flatCoords, endss := flattenPolygons(polygons)
+	return flatCoords, endss, nil
 }
 
-func fixPolygonToList(poly *geom.Polygon, shouldFixWinding bool) ([]*geom.Polygon, error) {
-	if poly.Layout() != geom.XY && poly.Layout() != geom.XYZ {
-		return nil, ErrUnsupportedLayout
+// enclose handles a single polygon that is wound clockwise, which means it
+// covers everything except its own area. It is turned into a polygon covering
+// the whole world with the original exterior as a hole. The winding is taken
+// from the signed area, which unlike the winding at the highest vertex is not
+// thrown by rings that run along a pole.
+func (c *cutter) enclose(layout geom.Layout, polygons [][][]float64) [][][]float64 {
+	stride := layout.Stride()
+	if len(polygons) != 1 || ringArea(polygons[0][0], stride) >= 0 {
+		return polygons
+	}
+
+	world := make([]float64, 0, 5*stride)
+	for idx, corner := range [][]float64{{-180, 90}, {-180, -90}, {180, -90}, {180, 90}, {-180, 90}} {
+		corner = append(corner, make([]float64, stride-2)...)
+		if idx > 0 {
+			world = appendDensified(world, lastCoord(world, stride), corner, c.opts.densifyStep)
+		}
+		world = append(world, corner...)
 	}
 
-	var (
-		polygons  []*geom.Polygon
-		interiors = make([][]geom.Coord, 0)
-	)
+	return [][][]float64{{world, polygons[0][0]}}
+}
 
-	numCoords := 2
-	if poly.Layout() == geom.XYZ {
-		numCoords = 3
+// fixPolygonToList cuts the polygon made up of rings and returns the resulting
+// polygons, each of which is a list of flat rings. asIs reports that the
+// polygon did not need to be cut and the result is the input rings.
+func (c *cutter) fixPolygonToList(layout geom.Layout, rings [][]float64) (polygons [][][]float64, asIs bool, err error) {
+	if layout != geom.XY && layout != geom.XYZ {
+		return nil, false, c.newError(-1, -1, ErrUnsupportedLayout, "layout "+layout.String())
 	}
 
-	exterior := normalize(poly.LinearRing(0).Coords())
-	segments := segment(exterior)
+	if len(rings) == 0 {
+		return nil, true, nil
+	}
 
-	if len(segments) == 0 {
-		if shouldFixWinding {
-			correctlyWoundPolygon, err := fixWinding(poly)
-			if err != nil {
-				return nil, err
-			}
+	stride := layout.Stride()
 
-			polygons = append(polygons, correctlyWoundPolygon)
-		} else {
-			polygons = append(polygons, poly)
+	repaired := false
+	if c.opts.reflectPoles {
+		rings, repaired = reflectPoles(rings, stride)
+	}
+
+	// spikes are found before the rings are repaired so that they are
+	// reported with the indexes of the input
+	if c.opts.repairSpikes {
+		var despiked bool
+		rings, despiked = c.repairSpikes(rings, stride)
+		repaired = repaired || despiked
+	}
+
+	if c.opts.repair {
+		var fixed bool
+		rings, fixed = repairRings(rings, stride)
+		repaired = repaired || fixed
+	}
+
+	if c.opts.validate {
+		if err := validatePolygon(c.polygon, rings, stride); err != nil {
+			return nil, false, err
 		}
+	}
 
-		return polygons, nil
+	if c.opts.densifyMax > 0 {
+		rings = c.densifyRings(rings, stride)
+		repaired = true
 	}
 
-	for idx := range poly.NumLinearRings() - 1 {
-		interior := poly.LinearRing(idx + 1)
-		interiorSegments := segment(interior.Coords())
-		if len(interiorSegments) > 0 {
-			if shouldFixWinding {
-				unwrapped := make([]float64, 0, len(interior.Coords())*numCoords)
+	if c.opts.makeValid {
+		polygons, err = c.cutValid(layout, rings)
+		asIs = false
+	} else {
+		polygons, asIs, err = c.cutRings(layout, rings)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if c.opts.repair {
+		var removed bool
+		// the rings are in the working frame, where a unit of area is
+		// scaleX*scaleY square units of the coordinate system
+		minArea := c.opts.minArea / (c.frame.scaleX * c.frame.scaleY)
+		polygons, removed = removeSlivers(polygons, stride, minArea)
+		repaired = repaired || removed
+	}
+
+	return polygons, asIs && !repaired, nil
+}
+
+// cutValid makes rings valid and cuts each of the resulting polygons
+func (c *cutter) cutValid(layout geom.Layout, rings [][]float64) ([][][]float64, error) {
+	polygons := make([][][]float64, 0)
+	for _, valid := range makeValid(rings, layout.Stride()) {
+		cut, _, err := c.cutRings(layout, valid)
+		if err != nil {
+			return nil, err
+		}
+
+		polygons = append(polygons, cut...)
+	}
+
+	return polygons, nil
+}
+
+// cutRings does the work of fixPolygonToList once the rings have been
+// prepared
+func (c *cutter) cutRings(layout geom.Layout, rings [][]float64) (polygons [][][]float64, asIs bool, err error) {
+	stride := layout.Stride()
+	interiors := make([][]float64, 0)
+
+	if err := c.cancelled(0, 0, len(rings), "rings"); err != nil {
+		return nil, false, err
+	}
+
+	exterior := c.normalize(rings[0], stride)
+	segments := c.segment(exterior, stride)
+
+	if len(segments) == 0 {
+		// the output convention still applies to polygons that are not cut
+		rings, wrapped := c.wrapRings(rings, stride)
+		if c.opts.fixWinding {
+			return [][][]float64{c.fixWinding(layout, rings)}, c.opts.inPlace, nil
+		}
+
+		return [][][]float64{rings}, !wrapped || c.opts.inPlace, nil
+	}
 
+	for idx, interior := range rings[1:] {
+		if err := c.cancelled(idx+1, idx+1, len(rings), "rings"); err != nil {
+			return nil, false, err
+		}
+
+		interiorSegments := c.segment(interior, stride)
+		if len(interiorSegments) > 0 {
+			if c.opts.fixWinding {
 				// unwrap coordinates
-				for _, coord := range interior.Coords() {
-					unwrapped = append(unwrapped, mod(coord[0], 360))
-					unwrapped = append(unwrapped, coord[1])
-					if numCoords == 3 {
-						unwrapped = append(unwrapped, coord[2])
-					}
+				unwrapped := append(c.scratch[:0], interior...)
+				for idx := 0; idx < len(unwrapped); idx += stride {
+					unwrapped[idx] = mod(unwrapped[idx], 360)
 				}
+				c.scratch = unwrapped
 
 				// if the interior ring is counter-clockwise, make it clockwise
-				if xy.IsRingCounterClockwise(poly.Layout(), unwrapped) {
-					coords := make([]geom.Coord, len(interior.Coords()))
-					for idx, val := range interior.Coords() {
-						coords[idx] = val.Clone()
-					}
-
-					slices.Reverse(coords)
-					interiorSegments = segment(coords)
+				if xy.IsRingCounterClockwise(layout, unwrapped) {
+					interiorSegments = c.segment(c.reverse(interior, stride), stride)
 				}
 			}
 
 			segments = append(segments, interiorSegments...)
 		} else {
-			interiors = append(interiors, interior.Coords())
+			interiors = append(interiors, interior)
 		}
 	}
 
-	segments = extendOverPoles(segments, shouldFixWinding)
-	polygons = buildPolygons(poly.Layout(), segments)
+	segments = extendOverPoles(segments, stride, c.opts.fixWinding, c.opts.densifyStep)
+	exteriors, err := c.buildPolygons(layout, segments)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, exterior := range exteriors {
+		polygons = append(polygons, [][]float64{exterior})
+	}
 
 	// add interiors to the correct polygons
-	for _, polygon := range polygons {
-		remaining := make([][]geom.Coord, 0, len(interiors))
-		for _, interior := range interiors {
-			interiorPolygon := geom.NewPolygon(poly.Layout()).MustSetCoords([][]geom.Coord{interior})
-			if Contains(interiorPolygon, polygon) {
-				err := polygon.Push(geom.NewLinearRing(polygon.Layout()).MustSetCoords(interior))
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				remaining = append(remaining, interior)
+	if err := c.assignInteriors(polygons, interiors, stride); err != nil {
+		return nil, false, err
+	}
+
+	return polygons, false, nil
+}
+
+// wrapRings brings the longitudes of rings that do not cross the seam into
+// [-180, 180]. Rings are changed in place if the cutter is allowed to modify
+// its input, otherwise those that change are copied. wrapped reports if any
+// ring changed.
+func (c *cutter) wrapRings(rings [][]float64, stride int) (fixed [][]float64, wrapped bool) {
+	fixed = rings
+	for idx, ring := range rings {
+		if !hasLonOutOfRange(ring, stride) {
+			continue
+		}
+
+		normalized := c.normalize(ring, stride)
+		if c.opts.inPlace {
+			copy(ring, normalized)
+		} else {
+			if !wrapped {
+				fixed = slices.Clone(rings)
 			}
+			fixed[idx] = slices.Clone(normalized)
 		}
+		wrapped = true
+	}
 
-		interiors = remaining
+	return fixed, wrapped
+}
+
+// hasLonOutOfRange checks if any longitude of ring is outside [-180, 180]
+func hasLonOutOfRange(ring []float64, stride int) bool {
+	for idx := 0; idx < len(ring); idx += stride {
+		if math.Abs(ring[idx]) > 180 {
+			return true
+		}
 	}
 
-	return polygons, nil
+	return false
+}
+
+// reverse reverses the order of the coordinates of ring, in place if the
+// cutter is allowed to modify its input
+func (c *cutter) reverse(ring []float64, stride int) []float64 {
+	if c.opts.inPlace {
+		reverseFlat(ring, stride)
+		return ring
+	}
+
+	return reversedFlat(ring, stride)
 }
 
 // fixWinding ensures that the exterior ring of the polygon is wound
 // counter-clockwise and all interior rings are wound clockwise
-func fixWinding(poly *geom.Polygon) (*geom.Polygon, error) {
-	fixed := geom.NewPolygon(poly.Layout())
+func (c *cutter) fixWinding(layout geom.Layout, rings [][]float64) [][]float64 {
+	fixed := make([][]float64, len(rings))
+	stride := layout.Stride()
+
+	for idx, ring := range rings {
+		// exterior ring should be wound counter-clockwise and all interior
+		// rings should be wound clockwise
+		if (idx == 0) != xy.IsRingCounterClockwise(layout, ring) {
+			ring = c.reverse(ring, stride)
+		}
 
-	exteriorCoords := poly.LinearRing(0).Coords()
-	if !xy.IsRingCounterClockwise(poly.Layout(), poly.LinearRing(0).FlatCoords()) {
-		// exterior ring should be wound counter-clockwise
-		slices.Reverse(exteriorCoords)
+		fixed[idx] = ring
 	}
 
-	fixed.MustSetCoords([][]geom.Coord{exteriorCoords})
+	return fixed
+}
 
-	// all interior rings should be wound clockwise
-	for idx := range poly.NumLinearRings() - 1 {
-		interior := poly.LinearRing(idx + 1)
-		coords := interior.Coords()
+// crossingOf returns the direction in which the edge from start to end crosses
+// the antimeridian. An edge between two points on the same pole does not move
+// and never crosses it.
+func crossingOf(start, end []float64) crossing {
+	switch {
+	case math.Abs(start[1]) == 90 && start[1] == end[1]:
+		return noCrossing
+	case (end[0]-start[0] > 180) && (end[0]-start[0] != 360):
+		return leftCrossing
+	case (start[0]-end[0] > 180) && (start[0]-end[0] != 360):
+		return rightCrossing
+	default:
+		return noCrossing
+	}
+}
 
-		if xy.IsRingCounterClockwise(poly.Layout(), interior.FlatCoords()) {
-			slices.Reverse(coords)
-		}
+func (c *cutter) segment(flatCoords []float64, stride int) [][]float64 {
+	numCoords := len(flatCoords) / stride
 
-		ring := geom.NewLinearRing(poly.Layout()).MustSetCoords(coords)
-		err := fixed.Push(ring)
-		if err != nil {
-			return nil, err
+	numCrossings := 0
+	for idx := range numCoords - 1 {
+		if crossingOf(coordAt(flatCoords, idx, stride), coordAt(flatCoords, idx+1, stride)) != noCrossing {
+			numCrossings++
 		}
 	}
 
-	return fixed, nil
-}
+	if numCrossings == 0 {
+		// no antimeridian crossings
+		return nil
+	}
 
-func segment(coords []geom.Coord) [][]geom.Coord {
-	currSegment := make([]geom.Coord, 0)
-	segments := make([][]geom.Coord, 0)
+	// the coordinates of every segment are written to buf, which is sliced up
+	// into the individual segments once the whole ring has been processed
+	buf := make([]float64, 0, len(flatCoords)+2*numCrossings*stride)
+	ends := make([]int, 0, numCrossings+1)
 
 	// create segments
-	for idx := range len(coords) - 1 {
-		start, end := coords[idx], coords[idx+1]
-		currSegment = append(currSegment, start)
-
-		switch {
-		case (end[0]-start[0] > 180) && (end[0]-start[0] != 360):
-			// left
-			latitude := crossingLat(start, end)
-			currSegment = append(currSegment, geom.Coord{-180.0, latitude})
-			segments = append(segments, currSegment)
-			currSegment = []geom.Coord{{180.0, latitude}}
-		case (start[0]-end[0] > 180) && (start[0]-end[0] != 360):
-			// right
-			latitude := crossingLat(end, start)
-			currSegment = append(currSegment, geom.Coord{180.0, latitude})
-			segments = append(segments, currSegment)
-			currSegment = []geom.Coord{{-180, latitude}}
+	for idx := range numCoords - 1 {
+		start, end := coordAt(flatCoords, idx, stride), coordAt(flatCoords, idx+1, stride)
+		buf = append(buf, start...)
+
+		switch crossingOf(start, end) {
+		case leftCrossing:
+			latitude := c.crossingLat(start, end)
+			buf = appendCrossing(buf, stride, -180.0, latitude)
+			ends = append(ends, len(buf))
+			buf = appendCrossing(buf, stride, 180.0, latitude)
+		case rightCrossing:
+			latitude := c.crossingLat(end, start)
+			buf = appendCrossing(buf, stride, 180.0, latitude)
+			ends = append(ends, len(buf))
+			buf = appendCrossing(buf, stride, -180.0, latitude)
+		case noCrossing:
 		}
 	}
 
-	switch {
-	case len(segments) == 0:
-		// no antimeridian crossings
-		return segments
-	case slices.Compare[[]float64](coords[len(coords)-1], segments[0][0]) == 0:
+	segments := make([][]float64, 0, len(ends)+1)
+	offset := 0
+	for _, end := range ends {
+		segments = append(segments, buf[offset:end:end])
+		offset = end
+	}
+
+	currSegment := buf[offset:len(buf):len(buf)]
+	if slices.Equal(lastCoord(flatCoords, stride), coordAt(segments[0], 0, stride)) {
 		// join polygons
 		segments[0] = append(currSegment, segments[0]...)
-	default:
-		currSegment = append(currSegment, coords[len(coords)-1])
-		segments = append(segments, currSegment)
+	} else {
+		segments = append(segments, append(currSegment, lastCoord(flatCoords, stride)...))
 	}
 
 	return segments
 }
 
-func crossingLat(start, end geom.Coord) float64 {
+// appendCrossing appends the point where an edge crosses the antimeridian to
+// dst. Ordinates beyond latitude are zero, as for the other points that
+// cutting adds.
+func appendCrossing(dst []float64, stride int, longitude, latitude float64) []float64 {
+	dst = append(dst, longitude, latitude)
+	for range stride - 2 {
+		dst = append(dst, 0)
+	}
+
+	return dst
+}
+
+func (c *cutter) crossingLat(start, end []float64) float64 {
 	switch {
 	case math.Abs(start[0]) == 180.0:
 		return start[1]
@@ -240,30 +494,42 @@ func crossingLat(start, end geom.Coord) float64 {
 
 	latDelta := end[1] - start[1]
 
+	var latitude float64
 	if end[0] > 0 {
-		return roundFloat(start[1]+(180.0-start[0])*latDelta/(end[0]+360.0-start[0]), 7)
+		latitude = start[1] + (180.0-start[0])*latDelta/(end[0]+360.0-start[0])
+	} else {
+		latitude = start[1] + (start[0]+180.0)*latDelta/(start[0]+360.0-end[0])
 	}
 
-	return roundFloat(start[1]+(start[0]+180.0)*latDelta/(start[0]+360.0-end[0]), 7)
+	if c.frame.isScaled() {
+		// a rounding error in scaled degrees is a large one in the units of
+		// the coordinate system, which are rounded on the way out instead
+		return latitude
+	}
+
+	return roundFloat(latitude, 7)
 }
 
-func extendOverPoles(segments [][]geom.Coord, shouldFixWinding bool) [][]geom.Coord {
+// extendOverPoles extends the segments that end nearest to a pole, with no
+// segment starting between them and the pole, over that pole. The edges added
+// are densified to step degrees.
+func extendOverPoles(segments [][]float64, stride int, shouldFixWinding bool, step float64) [][]float64 {
 	leftStarts := make([]edge, 0)
 	rightStarts := make([]edge, 0)
 	leftEnds := make([]edge, 0)
 	rightEnds := make([]edge, 0)
 
 	for idx, segment := range segments {
-		if segment[0][0] == -180 {
-			leftStarts = append(leftStarts, edge{Index: idx, Val: segment[0][1]})
+		if segment[0] == -180 {
+			leftStarts = append(leftStarts, edge{Index: idx, Val: segment[1]})
 		} else {
-			rightStarts = append(rightStarts, edge{Index: idx, Val: segment[0][1]})
+			rightStarts = append(rightStarts, edge{Index: idx, Val: segment[1]})
 		}
 
-		if segment[len(segment)-1][0] == -180 {
-			leftEnds = append(leftEnds, edge{Index: idx, Val: segment[len(segment)-1][1]})
+		if end := lastCoord(segment, stride); end[0] == -180 {
+			leftEnds = append(leftEnds, edge{Index: idx, Val: end[1]})
 		} else {
-			rightEnds = append(rightEnds, edge{Index: idx, Val: segment[len(segment)-1][1]})
+			rightEnds = append(rightEnds, edge{Index: idx, Val: end[1]})
 		}
 	}
 
@@ -275,178 +541,220 @@ func extendOverPoles(segments [][]geom.Coord, shouldFixWinding bool) [][]geom.Co
 	isOverNorthPole := false
 	isOverSouthPole := false
 
-	// deep copy segments
-	originalSegments := make([][]geom.Coord, len(segments))
-	for ii, sub := range segments {
-		originalSegments[ii] = make([]geom.Coord, len(sub))
-		for jj, val := range sub {
-			originalSegments[ii][jj] = val.Clone()
-		}
-	}
+	// remember the length of the segments before they are extended so the
+	// extension can be undone
+	var southIdx, southLen, northIdx, northLen int
 
 	// If there's no segment ends between a start and the pole, extend the
 	// segment over the pole.
 	if len(leftEnds) > 0 && (len(leftStarts) == 0 || leftEnds[0].Val < leftStarts[0].Val) {
 		isOverSouthPole = true
-		segments[leftEnds[0].Index] = append(segments[leftEnds[0].Index], geom.Coord{-180, -90}, geom.Coord{180, -90})
+		southIdx, southLen = leftEnds[0].Index, len(segments[leftEnds[0].Index])
+		segments[southIdx] = extendOverPole(segments[southIdx], stride, -180, -90, step)
 	}
 
 	if len(rightEnds) > 0 && (len(rightStarts) == 0 || rightEnds[0].Val > rightStarts[0].Val) {
 		isOverNorthPole = true
-		segments[rightEnds[0].Index] = append(segments[rightEnds[0].Index], geom.Coord{180, 90}, geom.Coord{-180, 90})
+		northIdx, northLen = rightEnds[0].Index, len(segments[rightEnds[0].Index])
+		segments[northIdx] = extendOverPole(segments[northIdx], stride, 180, 90, step)
 	}
 
 	if shouldFixWinding && isOverNorthPole && isOverSouthPole {
-		// If we're over both poles reverse all segments, effectively
-		// reversing the winding order.
-		for _, segment := range originalSegments {
-			slices.Reverse(segment)
+		// If we're over both poles reverse all original segments,
+		// effectively reversing the winding order.
+		segments[northIdx] = segments[northIdx][:northLen]
+		segments[southIdx] = segments[southIdx][:southLen]
+		for _, segment := range segments {
+			reverseFlat(segment, stride)
 		}
-
-		return originalSegments
 	}
 
 	return segments
 }
 
-func buildPolygons(layout geom.Layout, segments [][]geom.Coord) []*geom.Polygon {
-	if len(segments) == 0 {
-		return []*geom.Polygon{}
-	}
-
-	// pop last segment off list
-	segment := segments[len(segments)-1]
-	segments = segments[:len(segments)-1]
+// extendOverPole extends a segment ending on the antimeridian at lon to the
+// pole at lat and across it to the other side of the antimeridian. The pole
+// points take their extra ordinates from the end of the segment.
+func extendOverPole(segment []float64, stride int, lon, lat, step float64) []float64 {
+	end := lastCoord(segment, stride)
+	corner := appendCoord(nil, lon, lat, end)
+	other := appendCoord(nil, -lon, lat, end)
+
+	segment = appendDensified(segment, end, corner, step)
+	segment = append(segment, corner...)
+	segment = appendDensified(segment, corner, other, step)
+	return append(segment, other...)
+}
 
-	segmentEnd := segment[len(segment)-1]
-	isRight := segmentEnd[0] == 180
+func (c *cutter) buildPolygons(layout geom.Layout, segments [][]float64) ([][]float64, error) {
+	stride := layout.Stride()
+	polygons := make([][]float64, 0)
 
-	candidates := make([]edge, 0)
-	if isSelfClosing(segment) {
-		// Self-closing segments might end up joining up with themselves. They
-		// might not, e.g. donuts.
-		candidates = append(candidates, edge{Index: -1, Val: segment[0][1]})
-	}
+	// every pass either joins two segments or closes one, so there are as
+	// many passes as there are segments
+	numSegments := len(segments)
+	for len(segments) > 0 {
+		if err := c.cancelled(-1, numSegments-len(segments), numSegments, "segments"); err != nil {
+			return nil, err
+		}
 
-	for idx, s0 := range segments {
-		// Is the start of s0 on the same side as the end of segment?
-		if s0[0][0] == segment[len(segment)-1][0] {
-			// If so, check the following:
-			// - Is the start of s0 closer to the pole than the end of segment, and
-			// - is the end of s0 on the other side, or
-			// - is the end of s0 further away from the pole than the start of
-			//   segment (e.g. donuts)?
+		// pop last segment off list
+		segment := segments[len(segments)-1]
+		segments = segments[:len(segments)-1]
 
-			startCloserToNorthPole := s0[0][1] > segmentEnd[1]
-			startCloserToSouthPole := s0[0][1] < segmentEnd[1]
+		segmentStart := coordAt(segment, 0, stride)
+		segmentEnd := lastCoord(segment, stride)
+		isRight := segmentEnd[0] == 180
 
-			s0End := s0[len(s0)-1]
-			endFurtherFromNorthPole := s0End[1] < segment[0][1]
-			endFurtherFromSouthPole := s0End[1] > segment[0][1]
+		candidates := make([]edge, 0)
+		if isSelfClosing(segment, stride) {
+			// Self-closing segments might end up joining up with themselves. They
+			// might not, e.g. donuts.
+			candidates = append(candidates, edge{Index: -1, Val: segmentStart[1]})
+		}
 
-			if (isRight && startCloserToNorthPole && (!isSelfClosing(s0) || endFurtherFromNorthPole)) ||
-				(!isRight && startCloserToSouthPole && (!isSelfClosing(s0) || endFurtherFromSouthPole)) {
-				candidates = append(candidates, edge{Index: idx, Val: s0[0][1]})
+		for idx, s0 := range segments {
+			// Is the start of s0 on the same side as the end of segment?
+			if s0[0] == segmentEnd[0] {
+				// If so, check the following:
+				// - Is the start of s0 closer to the pole than the end of segment, and
+				// - is the end of s0 on the other side, or
+				// - is the end of s0 further away from the pole than the start of
+				//   segment (e.g. donuts)?
+
+				startCloserToNorthPole := s0[1] > segmentEnd[1]
+				startCloserToSouthPole := s0[1] < segmentEnd[1]
+
+				s0End := lastCoord(s0, stride)
+				endFurtherFromNorthPole := s0End[1] < segmentStart[1]
+				endFurtherFromSouthPole := s0End[1] > segmentStart[1]
+
+				if (isRight && startCloserToNorthPole && (!isSelfClosing(s0, stride) || endFurtherFromNorthPole)) ||
+					(!isRight && startCloserToSouthPole && (!isSelfClosing(s0, stride) || endFurtherFromSouthPole)) {
+					candidates = append(candidates, edge{Index: idx, Val: s0[1]})
+				}
 			}
 		}
-	}
 
-	// Sort the candidates so the closest point is first in the list.
-	slices.SortFunc(candidates, cmp)
-	if !isRight {
-		slices.Reverse(candidates)
-	}
-
-	index := 0
-	if len(candidates) > 0 {
-		index = candidates[0].Index
-	} else {
-		index = -1
-	}
+		// Sort the candidates so the closest point is first in the list.
+		slices.SortFunc(candidates, cmp)
+		if !isRight {
+			slices.Reverse(candidates)
+		}
 
-	if index > -1 {
-		// Join the segments, then re-add them to the list and recurse.
-		segment = append(segment, segments[index]...)
-		segments = slices.Delete(segments, index, index+1)
-		segments = append(segments, segment)
+		index := -1
+		if len(candidates) > 0 {
+			index = candidates[0].Index
+		}
 
-		return buildPolygons(layout, segments)
-	}
+		if index > -1 {
+			// Join the segments, then re-add them to the list and keep going.
+			segment = appendDensified(segment, segmentEnd, coordAt(segments[index], 0, stride), c.opts.densifyStep)
+			segment = append(segment, segments[index]...)
+			segments = slices.Delete(segments, index, index+1)
+			segments = append(segments, segment)
 
-	// This segment should be self-joining, so just build the rest of the
-	// polygons without it.
-	polygons := buildPolygons(layout, segments)
+			continue
+		}
 
-	// If every point is the same, then we don't need it in the output
-	// set of polygons. This happens if, e.g., one corner of an input
-	// polygon is on the antimeridian.
-	allEqual := true
-	for _, pt := range segment {
-		allEqual = allEqual && (pt.Equal(layout, segment[0]))
-	}
+		// This segment should be self-joining. If every point is the same,
+		// then we don't need it in the output set of polygons. This happens
+		// if, e.g., one corner of an input polygon is on the antimeridian.
+		allEqual := true
+		for idx := stride; idx < len(segment); idx += stride {
+			allEqual = allEqual && slices.Equal(segment[idx:idx+stride], segmentStart)
+		}
 
-	if !allEqual {
-		// if the last element does not equal the first of the polygon
-		// close the polygon
-		first := segment[0]
-		last := segment[len(segment)-1]
+		if !allEqual {
+			// if the last element does not equal the first of the polygon
+			// close the polygon
+			if !slices.Equal(segmentStart, segmentEnd) {
+				segment = appendDensified(segment, segmentEnd, segmentStart, c.opts.densifyStep)
+				segment = append(segment, segmentStart...)
+			}
 
-		if !first.Equal(layout, last) {
-			segment = append(segment, first.Clone())
+			polygons = append(polygons, segment)
 		}
-
-		polygon := geom.NewPolygon(layout).MustSetCoords([][]geom.Coord{segment})
-		polygons = append(polygons, polygon)
 	}
 
-	return polygons
+	// polygons are closed in the reverse order that they are returned in
+	slices.Reverse(polygons)
+
+	return polygons, nil
 }
 
-func isSelfClosing(segment []geom.Coord) bool {
-	segmentEnd := segment[len(segment)-1]
+func isSelfClosing(segment []float64, stride int) bool {
+	segmentEnd := lastCoord(segment, stride)
 	isRight := segmentEnd[0] == 180
-	return segment[0][0] == segmentEnd[0] &&
-		((isRight && segment[0][1] > segmentEnd[1]) ||
-			(!isRight && segment[0][1] < segmentEnd[1]))
+	return segment[0] == segmentEnd[0] &&
+		((isRight && segment[1] > segmentEnd[1]) ||
+			(!isRight && segment[1] < segmentEnd[1]))
 }
 
-func normalize(coords []geom.Coord) []geom.Coord {
-	// make a copy of the original coordinates
-	original := make([]geom.Coord, len(coords))
-	for idx, v := range coords {
-		original[idx] = v.Clone()
-	}
-
-	allAreOnAntiMeridian := true
+// normalize ensures all longitudes are between -180 and 180. The result is
+// written to the cutter's scratch space, flatCoords is left untouched.
+func (c *cutter) normalize(flatCoords []float64, stride int) []float64 {
 	// Ensure all longitudes are between -180 and 180, and that tiny floating
 	// point differences are ignored
 	tol := 1e-08
-	for idx, point := range coords {
+
+	allAreOnAntiMeridian := true
+	for idx := 0; idx < len(flatCoords); idx += stride {
+		if math.Abs(math.Abs(flatCoords[idx])-180.0) > tol {
+			allAreOnAntiMeridian = false
+			break
+		}
+	}
+
+	if allAreOnAntiMeridian {
+		return flatCoords
+	}
+
+	// the normalized coordinates are only valid until the scratch space is
+	// used again
+	coords := append(c.scratch[:0], flatCoords...)
+	c.scratch = coords[:0]
+
+	for idx := 0; idx < len(coords); idx += stride {
+		// the previous point has already been normalized, except for the
+		// first point where it is the last point of the ring
+		prevIdx := idx - stride
+		if prevIdx < 0 {
+			prevIdx = len(coords) - stride
+		}
+
+		lon, lat := coords[idx], coords[idx+1]
 		switch {
-		case math.Abs(point[0]-180.0) <= tol:
-			wrappedPrevIdx := int(mod(float64(idx-1), float64(len(coords))))
-			if math.Abs(point[1]) != 90 && math.Abs(coords[wrappedPrevIdx][0]+180) <= tol {
-				coords[idx] = geom.Coord{-180.0, point[1]}
+		case c.frame.inferSeamSide && math.Abs(lat) != 90 && math.Abs(mod(lon, 360)-180) <= tol:
+			// the vertex goes on the same side of the seam as the one before
+			// it, which for the first vertex is the one before the closing
+			// vertex
+			prevLon := coords[prevIdx]
+			if idx == 0 && len(coords) > 2*stride {
+				prevLon = mod(coords[len(coords)-2*stride]+180, 360) - 180
+			}
+
+			coords[idx] = 180.0
+			if prevLon < 0 {
+				coords[idx] = -180.0
+			}
+		case math.Abs(lon-180.0) <= tol:
+			if math.Abs(lat) != 90 && math.Abs(coords[prevIdx]+180) <= tol {
+				coords[idx] = -180.0
 			} else {
-				coords[idx] = geom.Coord{180.0, point[1]}
+				coords[idx] = 180.0
 			}
-		case math.Abs(point[0]+180) <= tol:
-			wrappedPrevIdx := int(mod(float64(idx-1), float64(len(coords))))
-			if math.Abs(point[1]) != 90 && math.Abs(coords[wrappedPrevIdx][0]-180) <= tol {
-				coords[idx] = geom.Coord{180.0, point[1]}
+		case math.Abs(lon+180) <= tol:
+			if math.Abs(lat) != 90 && math.Abs(coords[prevIdx]-180) <= tol {
+				coords[idx] = 180.0
 			} else {
-				coords[idx] = geom.Coord{-180.0, point[1]}
+				coords[idx] = -180.0
 			}
 		default:
-			coords[idx] = geom.Coord{mod(point[0]+180.0, 360.0) - 180.0, point[1]}
-			allAreOnAntiMeridian = false
+			coords[idx] = mod(lon+180.0, 360.0) - 180.0
 		}
 	}
 
-	if allAreOnAntiMeridian {
-		return original
-	}
-
 	return coords
 }
 
@@ -79,3 +79,74 @@ var _ = DescribeTable("Various Polygons",
 	Entry("split", "split", "split", true),
 	Entry("two holes", "two-holes", "two-holes", true),
 )
+
+var _ = Describe("Polygons with many holes", func() {
+	It("assigns every hole to the piece that contains it", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, -10}, {-170, -10}, {-170, 10}, {170, 10}, {170, -10}},
+			{{172, -5}, {172, 5}, {175, 5}, {175, -5}, {172, -5}},
+			{{-175, -5}, {-175, 5}, {-172, 5}, {-172, -5}, {-175, -5}},
+			{{176, -5}, {176, 5}, {178, 5}, {178, -5}, {176, -5}},
+			{{10, -5}, {10, 5}, {12, 5}, {12, -5}, {10, -5}},
+		})
+
+		result, err := antimeridian.Cut(polygon)
+		Expect(err).To(BeNil())
+
+		multiPolygon, ok := result.(*geom.MultiPolygon)
+		Expect(ok).To(BeTrue())
+		Expect(multiPolygon.NumPolygons()).To(Equal(2))
+
+		for idx := range multiPolygon.NumPolygons() {
+			piece := multiPolygon.Polygon(idx)
+			exterior := piece.LinearRing(0)
+			for ringIdx := 1; ringIdx < piece.NumLinearRings(); ringIdx++ {
+				for _, pt := range piece.LinearRing(ringIdx).Coords() {
+					Expect(antimeridian.ContainsPoint(pt, exterior)).To(BeTrue())
+				}
+			}
+		}
+
+		// the hole outside of the polygon is dropped
+		Expect(multiPolygon.Polygon(0).NumLinearRings() + multiPolygon.Polygon(1).NumLinearRings()).To(Equal(5))
+	})
+})
+
+var _ = Describe("Flat coordinates", func() {
+	It("cuts a polygon the same way as Cut", func() {
+		inGeom := readInput("split")
+
+		expected, err := antimeridian.Cut(inGeom)
+		Expect(err).To(BeNil())
+
+		flatCoords, endss, err := antimeridian.CutFlat(inGeom.Layout(), inGeom.FlatCoords(), inGeom.Ends())
+		Expect(err).To(BeNil())
+		Expect(flatCoords).To(Equal(expected.FlatCoords()))
+		Expect(endss).To(Equal(expected.Endss()))
+	})
+
+	It("does not share the input of polygons that are not cut", func() {
+		flatCoords := []float64{10, 0, 20, 0, 20, 10, 10, 0}
+		ends := []int{8}
+
+		result, endss, err := antimeridian.CutFlat(geom.XY, flatCoords, ends)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(flatCoords))
+		Expect(endss).To(Equal([][]int{ends}))
+
+		result[0], endss[0][0] = 15, 6
+		Expect(flatCoords[0]).To(Equal(10.0))
+		Expect(ends[0]).To(Equal(8))
+	})
+
+	It("fixes the winding of the input when cutting in place", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{100, 40}, {90, 40}, {90, 50}, {100, 50}, {100, 40}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithInPlace(true))
+		Expect(err).To(BeNil())
+		Expect(result).To(BeIdenticalTo(polygon))
+		Expect(polygon.FlatCoords()).To(Equal([]float64{100, 40, 100, 50, 90, 50, 90, 40, 100, 40}))
+	})
+})
@@ -0,0 +1,326 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package project reprojects longitude and latitude geometries, such as the
+// output of antimeridian.Cut, to Web Mercator and to the polar stereographic
+// projections of the Arctic and the Antarctic.
+package project
+
+import (
+	"errors"
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	"github.com/twpayne/go-geom"
+)
+
+// ErrOutOfRange is returned for coordinates that a projection can't represent,
+// such as the south pole in a north polar stereographic projection
+var ErrOutOfRange = errors.New("coordinate is outside of the projection")
+
+// Projection is a map projection from longitude and latitude, in degrees on
+// WGS 84, to x and y in metres
+type Projection int
+
+const (
+	// WebMercator is EPSG:3857. Latitudes beyond MercatorLatitudeLimit are
+	// clamped to it, so that the pole edges of Cut output stay finite.
+	WebMercator Projection = iota
+	// NorthPolarStereographic is EPSG:3413, NSIDC Sea Ice Polar
+	// Stereographic North
+	NorthPolarStereographic
+	// SouthPolarStereographic is EPSG:3031, Antarctic Polar Stereographic
+	SouthPolarStereographic
+)
+
+// String returns the EPSG code of the projection
+func (p Projection) String() string {
+	switch p {
+	case WebMercator:
+		return "EPSG:3857"
+	case NorthPolarStereographic:
+		return "EPSG:3413"
+	case SouthPolarStereographic:
+		return "EPSG:3031"
+	default:
+		return "unknown"
+	}
+}
+
+// Forward projects a single longitude and latitude
+func (p Projection) Forward(lon, lat float64) (x, y float64, err error) {
+	if math.IsNaN(lon) || math.IsNaN(lat) || math.Abs(lat) > 90 {
+		return 0, 0, ErrOutOfRange
+	}
+
+	switch p {
+	case WebMercator:
+		x, y = mercator(lon, lat)
+	case NorthPolarStereographic:
+		if lat == -90 {
+			return 0, 0, ErrOutOfRange
+		}
+		x, y = northStereographic.forward(lon, lat)
+	case SouthPolarStereographic:
+		if lat == 90 {
+			return 0, 0, ErrOutOfRange
+		}
+		// the south polar projection is the north polar one seen from below
+		x, y = southStereographic.forward(-lon, -lat)
+		x, y = -x, -y
+	default:
+		return 0, 0, ErrOutOfRange
+	}
+
+	return x, y, nil
+}
+
+// Project reprojects a point, line string, polygon or their multi versions.
+// Consecutive vertices that land on the same point are collapsed into one, so
+// the edges that Cut adds along a pole disappear in a polar projection. Rings
+// that then run out to a point and straight back, as a polar cap does to its
+// pole, have the spike removed. Rings left with fewer than four coordinates
+// are dropped, along with polygons that lose their exterior ring. Any
+// ordinates after x and y are kept. obj is not modified.
+func Project(obj geom.T, p Projection) (geom.T, error) {
+	switch geometry := obj.(type) {
+	case *geom.Point:
+		flatCoords, err := p.project(geometry.FlatCoords(), geometry.Stride())
+		if err != nil {
+			return nil, err
+		}
+
+		return geom.NewPointFlat(geometry.Layout(), flatCoords), nil
+	case *geom.MultiPoint:
+		flatCoords, err := p.project(geometry.FlatCoords(), geometry.Stride())
+		if err != nil {
+			return nil, err
+		}
+
+		return geom.NewMultiPointFlat(geometry.Layout(), flatCoords), nil
+	case *geom.LineString:
+		flatCoords, err := p.projectLine(geometry.FlatCoords(), geometry.Stride())
+		if err != nil {
+			return nil, err
+		}
+
+		return geom.NewLineStringFlat(geometry.Layout(), flatCoords), nil
+	case *geom.MultiLineString:
+		flatCoords, ends, err := p.projectLines(geometry.FlatCoords(), 0, geometry.Ends(), geometry.Stride())
+		if err != nil {
+			return nil, err
+		}
+
+		return geom.NewMultiLineStringFlat(geometry.Layout(), flatCoords, ends), nil
+	case *geom.Polygon:
+		flatCoords, ends, err := p.projectPolygon(nil, geometry.FlatCoords(), 0, geometry.Ends(), geometry.Stride())
+		if err != nil {
+			return nil, err
+		}
+
+		return geom.NewPolygonFlat(geometry.Layout(), flatCoords, ends), nil
+	case *geom.MultiPolygon:
+		var flatCoords []float64
+		endss := make([][]int, 0, len(geometry.Endss()))
+		offset := 0
+		for _, ends := range geometry.Endss() {
+			var (
+				newEnds []int
+				err     error
+			)
+			flatCoords, newEnds, err = p.projectPolygon(flatCoords, geometry.FlatCoords(), offset, ends, geometry.Stride())
+			if err != nil {
+				return nil, err
+			}
+
+			if len(newEnds) > 0 {
+				endss = append(endss, newEnds)
+			}
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+
+		return geom.NewMultiPolygonFlat(geometry.Layout(), flatCoords, endss), nil
+	default:
+		return nil, antimeridian.ErrUnsupportedType
+	}
+}
+
+// project projects every coordinate of flatCoords into a new slice
+func (p Projection) project(flatCoords []float64, stride int) ([]float64, error) {
+	projected := make([]float64, len(flatCoords))
+	copy(projected, flatCoords)
+	for idx := 0; idx < len(projected); idx += stride {
+		x, y, err := p.Forward(projected[idx], projected[idx+1])
+		if err != nil {
+			return nil, err
+		}
+
+		projected[idx], projected[idx+1] = x, y
+	}
+
+	return projected, nil
+}
+
+// projectLine projects a line and collapses consecutive coordinates that land
+// on the same point
+func (p Projection) projectLine(flatCoords []float64, stride int) ([]float64, error) {
+	projected, err := p.project(flatCoords, stride)
+	if err != nil {
+		return nil, err
+	}
+
+	return collapse(projected, stride), nil
+}
+
+// projectRing projects a ring like projectLine, keeping it closed
+func (p Projection) projectRing(flatCoords []float64, stride int) ([]float64, error) {
+	ring, err := p.projectLine(flatCoords, stride)
+	if err != nil || len(ring) < 2*stride {
+		return ring, err
+	}
+
+	// the last coordinate can be collapsed into one that is only close to the
+	// first
+	last := len(ring) - stride
+	ring[last], ring[last+1] = ring[0], ring[1]
+
+	return removeBacktracks(ring, stride), nil
+}
+
+// projectLines projects the lines of flatCoords from offset to ends. Lines
+// left with fewer than two coordinates are dropped.
+func (p Projection) projectLines(flatCoords []float64, offset int, ends []int, stride int) ([]float64, []int, error) {
+	return p.appendLines(nil, flatCoords, offset, ends, stride, false)
+}
+
+// appendLines projects the lines of flatCoords from offset to ends and appends
+// them to dst. If rings is set the lines are projected as rings and dropped if
+// left with fewer than four coordinates. The returned ends are offsets into
+// dst.
+func (p Projection) appendLines(dst, flatCoords []float64, offset int, ends []int, stride int, rings bool) ([]float64, []int, error) {
+	projectLine, minCoords := p.projectLine, 2
+	if rings {
+		projectLine, minCoords = p.projectRing, 4
+	}
+
+	newEnds := make([]int, 0, len(ends))
+	for _, end := range ends {
+		line, err := projectLine(flatCoords[offset:end], stride)
+		if err != nil {
+			return nil, nil, err
+		}
+		offset = end
+
+		if len(line) < minCoords*stride {
+			continue
+		}
+
+		dst = append(dst, line...)
+		newEnds = append(newEnds, len(dst))
+	}
+
+	return dst, newEnds, nil
+}
+
+// projectPolygon appends the projected polygon to dst. Its ends are empty if
+// the exterior ring collapsed.
+func (p Projection) projectPolygon(dst, flatCoords []float64, offset int, ends []int, stride int) ([]float64, []int, error) {
+	if len(ends) == 0 {
+		return dst, nil, nil
+	}
+
+	shell, err := p.projectRing(flatCoords[offset:ends[0]], stride)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(shell) < 4*stride {
+		return dst, nil, nil
+	}
+
+	dst = append(dst, shell...)
+	shellEnd := len(dst)
+	dst, holeEnds, err := p.appendLines(dst, flatCoords, ends[0], ends[1:], stride, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dst, append([]int{shellEnd}, holeEnds...), nil
+}
+
+// collapseTolerance is the distance, in metres, below which consecutive
+// projected coordinates are taken to be the same point
+const collapseTolerance = 1e-6
+
+// collapse removes coordinates that are within collapseTolerance of the one
+// before them in place
+func collapse(flatCoords []float64, stride int) []float64 {
+	out := 0
+	for idx := 0; idx < len(flatCoords); idx += stride {
+		if out > 0 && samePoint(flatCoords[idx:], flatCoords[out-stride:]) {
+			continue
+		}
+
+		copy(flatCoords[out:out+stride], flatCoords[idx:idx+stride])
+		out += stride
+	}
+
+	return flatCoords[:out]
+}
+
+// removeBacktracks removes the vertices of a closed ring where it turns back
+// on itself, along with the repeated vertex after each, in place. The ring is
+// empty if nothing is left of it.
+func removeBacktracks(ring []float64, stride int) []float64 {
+	// the ring is worked on without its closing coordinate, which is added
+	// back at the end
+	out := 0
+	for idx := 0; idx < len(ring)-stride; idx += stride {
+		switch {
+		case out >= stride && samePoint(ring[idx:], ring[out-stride:]):
+		case out >= 2*stride && samePoint(ring[idx:], ring[out-2*stride:]):
+			out -= stride
+		default:
+			copy(ring[out:out+stride], ring[idx:idx+stride])
+			out += stride
+		}
+	}
+
+	// the ends of the ring can turn back across the start of it too
+	vertices := ring[:out]
+	for len(vertices) >= 3*stride {
+		last := len(vertices) - stride
+		switch {
+		case samePoint(vertices[last:], vertices):
+			vertices = vertices[:last]
+		case samePoint(vertices[last:], vertices[stride:]):
+			vertices = vertices[2*stride:]
+		case samePoint(vertices[last-stride:], vertices):
+			vertices = vertices[stride:last]
+		default:
+			copy(ring, vertices)
+			return append(ring[:len(vertices)], ring[:stride]...)
+		}
+	}
+
+	return ring[:0]
+}
+
+// samePoint checks if the coordinates starting at a and b are within
+// collapseTolerance of each other
+func samePoint(a, b []float64) bool {
+	return math.Abs(a[0]-b[0]) <= collapseTolerance && math.Abs(a[1]-b[1]) <= collapseTolerance
+}
@@ -0,0 +1,120 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project_test
+
+import (
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	"github.com/go-geospatial/antimeridian/project"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+const halfWidth = math.Pi * 6378137
+
+var _ = Describe("Forward", func() {
+	DescribeTable("projects coordinates",
+		func(p project.Projection, lon, lat, x, y float64) {
+			px, py, err := p.Forward(lon, lat)
+			Expect(err).To(BeNil())
+			Expect(px).To(BeNumerically("~", x, 0.01))
+			Expect(py).To(BeNumerically("~", y, 0.01))
+		},
+		Entry("web mercator origin", project.WebMercator, 0.0, 0.0, 0.0, 0.0),
+		Entry("web mercator antimeridian", project.WebMercator, 180.0, 0.0, halfWidth, 0.0),
+		Entry("web mercator clamps the pole", project.WebMercator, -180.0, 90.0, -halfWidth, halfWidth),
+		Entry("north pole", project.NorthPolarStereographic, 30.0, 90.0, 0.0, 0.0),
+		Entry("south pole", project.SouthPolarStereographic, 30.0, -90.0, 0.0, 0.0),
+		// EPSG Guidance Note 7-2 example for polar stereographic variant B,
+		// moved from a longitude of origin of 70°E to the 0° of EPSG:3031
+		Entry("antarctic", project.SouthPolarStereographic, 50.0, -75.0, 1255380.79, 1053389.56),
+	)
+
+	It("is true to scale along 70°N in EPSG:3413", func() {
+		const eccentricitySquared = 0.0066943799901413165
+		sinLat := math.Sin(70 * math.Pi / 180)
+		radius := 6378137 * math.Cos(70*math.Pi/180) / math.Sqrt(1-eccentricitySquared*sinLat*sinLat)
+
+		x, y, err := project.NorthPolarStereographic.Forward(-45, 70)
+		Expect(err).To(BeNil())
+		Expect(x).To(BeNumerically("~", 0, 1e-6))
+		Expect(y).To(BeNumerically("~", -radius, 1e-6))
+	})
+
+	It("rejects the opposite pole", func() {
+		_, _, err := project.NorthPolarStereographic.Forward(0, -90)
+		Expect(err).To(MatchError(project.ErrOutOfRange))
+
+		_, _, err = project.SouthPolarStereographic.Forward(0, 90)
+		Expect(err).To(MatchError(project.ErrOutOfRange))
+	})
+})
+
+var _ = Describe("Project", func() {
+	// polarCap is cut into a polygon with edges along the north pole
+	polarCap := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{-170, 80}, {-90, 80}, {0, 80}, {90, 80}, {170, 80}, {-170, 80}},
+	})
+
+	It("collapses pole edges in polar stereographic", func() {
+		cut, err := antimeridian.Cut(polarCap)
+		Expect(err).To(BeNil())
+
+		result, err := project.Project(cut, project.NorthPolarStereographic)
+		Expect(err).To(BeNil())
+
+		coords := result.(*geom.Polygon).LinearRing(0).Coords()
+		Expect(len(coords)).To(BeNumerically(">=", 4))
+		Expect(coords[0]).To(Equal(coords[len(coords)-1]))
+
+		// the ring does not run out to the pole and back
+		vertices := coords[:len(coords)-1]
+		for ii, coord := range vertices {
+			Expect(math.Hypot(coord.X(), coord.Y())).To(BeNumerically(">", 1e5))
+			for _, other := range vertices[ii+1:] {
+				Expect(coord).NotTo(Equal(other))
+			}
+		}
+	})
+
+	It("drops polygons that collapse", func() {
+		polar := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{0, 86}, {10, 86}, {10, 88}, {0, 88}, {0, 86}}},
+			{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+		})
+
+		result, err := project.Project(polar, project.WebMercator)
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(1))
+	})
+
+	It("keeps extra ordinates", func() {
+		line := geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 5, 90, 0, 7})
+
+		result, err := project.Project(line, project.WebMercator)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()[2]).To(Equal(5.0))
+		Expect(result.FlatCoords()[5]).To(Equal(7.0))
+		Expect(line.FlatCoords()[3]).To(Equal(90.0))
+	})
+
+	It("rejects unsupported geometries", func() {
+		_, err := project.Project(geom.NewGeometryCollection(), project.WebMercator)
+		Expect(err).To(MatchError(antimeridian.ErrUnsupportedType))
+	})
+})
@@ -0,0 +1,83 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+)
+
+// the WGS 84 ellipsoid
+const (
+	semiMajorAxis = 6378137
+	flattening    = 1 / 298.257223563
+)
+
+// eccentricity of the WGS 84 ellipsoid
+var eccentricity = math.Sqrt(flattening * (2 - flattening))
+
+// mercator projects to the spherical Mercator of EPSG:3857
+func mercator(lon, lat float64) (x, y float64) {
+	lat = math.Max(-antimeridian.MercatorLatitudeLimit, math.Min(antimeridian.MercatorLatitudeLimit, lat))
+	return semiMajorAxis * toRadians(lon), semiMajorAxis * math.Log(math.Tan(math.Pi/4+toRadians(lat)/2))
+}
+
+// stereographic is a north polar stereographic projection of the WGS 84
+// ellipsoid, following Snyder, Map Projections: A Working Manual, p. 161
+type stereographic struct {
+	// centralLon is the longitude, in radians, that points down from the pole
+	centralLon float64
+	// scale is the distance from the pole per unit of t
+	scale float64
+}
+
+var (
+	northStereographic = newStereographic(-45, 70)
+	// southStereographic is applied to reflected coordinates, so its
+	// parameters are reflected too
+	southStereographic = newStereographic(0, 71)
+)
+
+// newStereographic returns the projection with the given central longitude and
+// latitude of true scale, in degrees
+func newStereographic(centralLon, trueScaleLat float64) stereographic {
+	phi := toRadians(trueScaleLat)
+	sinPhi := math.Sin(phi)
+	m := math.Cos(phi) / math.Sqrt(1-eccentricity*eccentricity*sinPhi*sinPhi)
+
+	return stereographic{
+		centralLon: toRadians(centralLon),
+		scale:      semiMajorAxis * m / isometricT(phi),
+	}
+}
+
+func (s stereographic) forward(lon, lat float64) (x, y float64) {
+	rho := s.scale * isometricT(toRadians(lat))
+	theta := toRadians(lon) - s.centralLon
+
+	return rho * math.Sin(theta), -rho * math.Cos(theta)
+}
+
+// isometricT is Snyder's t, which is zero at the north pole
+func isometricT(phi float64) float64 {
+	esinPhi := eccentricity * math.Sin(phi)
+	return math.Tan(math.Pi/4-phi/2) / math.Pow((1-esinPhi)/(1+esinPhi), eccentricity/2)
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,225 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+)
+
+// reflectPoles brings latitudes beyond ±90 back into range by reflecting them
+// over the pole, which moves the point to the opposite longitude. Edges that
+// run over a pole are split there, so the ring goes up to the pole on one side
+// and comes down from it on the other. Rings that need no reflection are
+// returned as they are, others are copied. changed reports if any ring was.
+func reflectPoles(rings [][]float64, stride int) (reflected [][]float64, changed bool) {
+	reflected = rings
+	for ringIdx, ring := range rings {
+		if !beyondPoles(ring, stride) {
+			continue
+		}
+
+		if !changed {
+			reflected = slices.Clone(rings)
+			changed = true
+		}
+
+		reflected[ringIdx] = reflectRing(ring, stride)
+	}
+
+	return reflected, changed
+}
+
+// beyondPoles checks if any latitude of ring is beyond ±90
+func beyondPoles(ring []float64, stride int) bool {
+	for idx := 1; idx < len(ring); idx += stride {
+		if ring[idx] < -90 || ring[idx] > 90 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reflectRing returns a copy of ring with its latitudes reflected over the
+// poles and points added where its edges run over them. Reflecting a part of
+// the ring over a pole mirrors it, so parts that pass over a pole an odd number
+// of times are traversed backwards to keep the winding of the ring.
+func reflectRing(ring []float64, stride int) []float64 {
+	if len(ring) < 2*stride {
+		return slices.Clone(ring)
+	}
+
+	// the ring is built without its closing coordinate, noting which points
+	// are beyond a pole an odd number of times
+	reflected := make([]float64, 0, len(ring)+4*stride)
+	odd := make([]bool, 0, len(ring)/stride+4)
+	for idx := 0; idx < len(ring)-stride; idx += stride {
+		if idx > 0 {
+			reflected, odd = appendPoleCrossings(reflected, odd, ring[idx-stride:idx], ring[idx:idx+stride], stride)
+		}
+
+		vertex := slices.Clone(ring[idx : idx+stride])
+		if !isNotFinite(vertex[1]) {
+			vertex[0], vertex[1] = reflectPole(vertex[0], vertex[1])
+		}
+
+		// a vertex on a pole can repeat the point added for the edge before it
+		if len(reflected) > 0 && sameXY(lastCoord(reflected, stride), vertex) {
+			continue
+		}
+		reflected = append(reflected, vertex...)
+		odd = append(odd, poleTrips(ring[idx+1])%2 != 0)
+	}
+	reflected, odd = appendPoleCrossings(reflected, odd, ring[len(ring)-2*stride:], lastCoord(ring, stride), stride)
+
+	reverseOddRuns(reflected, odd, stride)
+
+	return append(reflected, reflected[:stride]...)
+}
+
+// reverseOddRuns reverses the runs of points of an unclosed ring that are
+// beyond a pole an odd number of times, in place. Runs that start and end at
+// the same pole are reversed along with the points on the pole, so the ring
+// still goes up to the pole and comes back down from it. A ring that is
+// entirely beyond a pole is reversed as a whole.
+func reverseOddRuns(ring []float64, odd []bool, stride int) {
+	start := slices.Index(odd, false)
+	if start < 0 {
+		reverseFlat(ring, stride)
+		return
+	}
+
+	// the ring is rotated to start at a point that is not beyond a pole, so
+	// no run wraps around its end
+	rotateLeft(ring, start*stride)
+	rotateLeft(odd, start)
+
+	numPoints := len(odd)
+	for first := 0; first < numPoints; first++ {
+		if !odd[first] {
+			continue
+		}
+
+		last := first
+		for last+1 < numPoints && odd[last+1] {
+			last++
+		}
+
+		firstLat, lastLat := ring[first*stride+1], ring[last*stride+1]
+		if math.Abs(firstLat) == 90 && firstLat == lastLat {
+			reverseFlat(ring[first*stride:(last+1)*stride], stride)
+		}
+
+		first = last
+	}
+}
+
+// rotateLeft rotates values left by offset in place
+func rotateLeft[T any](values []T, offset int) {
+	slices.Reverse(values[:offset])
+	slices.Reverse(values[offset:])
+	slices.Reverse(values)
+}
+
+// appendPoleCrossings appends the points where the edge from start to end runs
+// over a pole to dst, once on the side of the pole that the edge comes from
+// and once on the side that it goes to, noting in odd whether each is beyond a
+// pole an odd number of times
+func appendPoleCrossings(dst []float64, odd []bool, start, end []float64, stride int) ([]float64, []bool) {
+	if isNotFinite(start[1]) || isNotFinite(end[1]) {
+		return dst, odd
+	}
+
+	from, to := poleTrips(start[1]), poleTrips(end[1])
+	step := 1
+	if to < from {
+		step = -1
+	}
+
+	for trips := from; trips != to; trips += step {
+		// the pole that is passed on going from trips to trips+step
+		passed := min(trips, trips+step)
+		poleLat := 90.0
+		if passed%2 != 0 {
+			poleLat = -90
+		}
+
+		// the longitude is interpolated the short way round, as the edge may
+		// cross the antimeridian as well
+		crossing := 90 + 180*float64(passed)
+		delta := end[0] - start[0]
+		delta -= 360 * math.Round(delta/360)
+		lon := start[0] + (crossing-start[1])/(end[1]-start[1])*delta
+		if lon < -180 || lon > 180 {
+			lon = mod(lon+180, 360) - 180
+		}
+		for _, side := range []int{trips, trips + step} {
+			sideLon := lon
+			if side%2 != 0 {
+				sideLon = oppositeLon(lon)
+			}
+
+			if len(dst) > 0 && sameXY(lastCoord(dst, stride), []float64{sideLon, poleLat}) {
+				continue
+			}
+			dst = appendCrossing(dst, stride, sideLon, poleLat)
+			odd = append(odd, side%2 != 0)
+		}
+	}
+
+	return dst, odd
+}
+
+// poleTrips returns the number of times a path has to pass over a pole to get
+// to lat, counting passes over the south pole as negative
+func poleTrips(lat float64) int {
+	switch {
+	case lat > 90:
+		return int(math.Ceil((lat - 90) / 180))
+	case lat < -90:
+		return -int(math.Ceil((-90 - lat) / 180))
+	default:
+		return 0
+	}
+}
+
+// oppositeLon returns the longitude on the other side of the poles from lon
+func oppositeLon(lon float64) float64 {
+	lon += 180
+	if lon > 180 {
+		lon -= 360
+	}
+
+	return lon
+}
+
+// reflectPole returns the point at lon and lat with the latitude brought
+// into [-90, 90] by travelling over the poles
+func reflectPole(lon, lat float64) (float64, float64) {
+	lat = mod(lat+180, 360) - 180
+	switch {
+	case lat > 90:
+		lat = 180 - lat
+	case lat < -90:
+		lat = -180 - lat
+	default:
+		// a whole number of trips around the globe
+		return lon, lat
+	}
+
+	return oppositeLon(lon), lat
+}
@@ -0,0 +1,74 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"errors"
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Pole reflection", func() {
+	It("covers the north pole with a ring that runs over it", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-10, 80}, {10, 80}, {10, 100}, {-10, 100}, {-10, 80}},
+		})
+
+		_, err := antimeridian.Cut(polygon)
+		Expect(errors.Is(err, antimeridian.ErrLatitudeOutOfRange)).To(BeTrue())
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithPoleReflection(true))
+		Expect(err).To(BeNil())
+
+		// the ring goes up to the pole at 10 and -10 and comes back down at
+		// -170 and 170, so it covers the pole and nothing south of 80
+		Expect(result.Bounds().Min(1)).To(Equal(80.0))
+		Expect(result.Bounds().Max(1)).To(Equal(90.0))
+		Expect(result.Bounds().Min(0)).To(Equal(-180.0))
+		Expect(result.Bounds().Max(0)).To(Equal(180.0))
+		Expect(ringArea(result.(*geom.Polygon))).To(BeNumerically("~", 400, 1e-9))
+
+		// the input is left untouched
+		Expect(polygon.FlatCoords()[5]).To(Equal(100.0))
+	})
+
+	It("reflects latitudes over the south pole", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, -85}, {175, -92}, {-175, -85}, {170, -85}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithPoleReflection(true))
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(ContainElements(-5.0, -88.0))
+		Expect(result.Bounds().Min(1)).To(Equal(-90.0))
+		Expect(result.Bounds().Max(1)).To(Equal(-85.0))
+
+		// the points are near the triangle or, where they were reflected,
+		// near the opposite longitude
+		coords := result.FlatCoords()
+		for idx := 0; idx < len(coords); idx += 2 {
+			lon := coords[idx]
+			Expect(math.Abs(lon) >= 170 || math.Abs(lon) <= 10).To(BeTrue(), "longitude %v", lon)
+		}
+		for _, violation := range antimeridian.Verify(result) {
+			Expect(violation.Kind).NotTo(Equal(antimeridian.ViolationCrossesAntimeridian))
+		}
+	})
+})
@@ -0,0 +1,145 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+)
+
+// repairRings closes any open rings and collapses consecutive duplicate
+// coordinates. Interior rings that have too few coordinates left to form a
+// ring are dropped, while the exterior is kept so that validation can report
+// it. changed reports if anything was repaired, otherwise rings is returned
+// untouched.
+func repairRings(rings [][]float64, stride int) (repaired [][]float64, changed bool) {
+	repaired = make([][]float64, 0, len(rings))
+	for idx, ring := range rings {
+		fixed, ringChanged := repairRing(ring, stride)
+		changed = changed || ringChanged
+
+		if idx > 0 && len(fixed) < 4*stride {
+			changed = true
+			continue
+		}
+
+		repaired = append(repaired, fixed)
+	}
+
+	if !changed {
+		return rings, false
+	}
+
+	return repaired, true
+}
+
+// repairRing collapses consecutive duplicate coordinates and closes the ring
+// if it is open. The ring is returned as-is if it needs neither.
+func repairRing(ring []float64, stride int) ([]float64, bool) {
+	if len(ring) == 0 {
+		return ring, false
+	}
+
+	fixed, changed := collapseDuplicates(ring, stride)
+
+	first, last := coordAt(fixed, 0, stride), lastCoord(fixed, stride)
+	switch {
+	case slices.Equal(first, last):
+	case sameXY(first, last):
+		// only the extra ordinates differ, so the last coordinate is
+		// replaced rather than adding a duplicate
+		fixed = slices.Clone(fixed)
+		copy(lastCoord(fixed, stride), first)
+		changed = true
+	default:
+		fixed = append(slices.Clip(fixed), first...)
+		changed = true
+	}
+
+	return fixed, changed
+}
+
+// removeSlivers collapses consecutive duplicate coordinates in every ring and
+// drops polygons and interior rings whose area is no more than minArea.
+// removed reports if anything was changed.
+func removeSlivers(polygons [][][]float64, stride int, minArea float64) (kept [][][]float64, removed bool) {
+	kept = make([][][]float64, 0, len(polygons))
+	for _, rings := range polygons {
+		cleaned := make([][]float64, 0, len(rings))
+		for idx, ring := range rings {
+			ring, changed := collapseDuplicates(ring, stride)
+			removed = removed || changed
+
+			if len(ring) < 4*stride || math.Abs(ringArea(ring, stride)) <= minArea {
+				removed = true
+				if idx == 0 {
+					// without an exterior the whole polygon goes
+					cleaned = nil
+					break
+				}
+
+				continue
+			}
+
+			cleaned = append(cleaned, ring)
+		}
+
+		if cleaned != nil {
+			kept = append(kept, cleaned)
+		}
+	}
+
+	return kept, removed
+}
+
+// collapseDuplicates removes consecutive coordinates that share the same x
+// and y from a closed ring
+func collapseDuplicates(ring []float64, stride int) ([]float64, bool) {
+	hasDuplicates := false
+	for idx := stride; idx < len(ring) && !hasDuplicates; idx += stride {
+		hasDuplicates = sameXY(ring[idx-stride:], ring[idx:])
+	}
+
+	if !hasDuplicates {
+		return ring, false
+	}
+
+	fixed := make([]float64, 0, len(ring))
+	fixed = append(fixed, coordAt(ring, 0, stride)...)
+	for idx := stride; idx < len(ring); idx += stride {
+		if !sameXY(lastCoord(fixed, stride), ring[idx:]) {
+			fixed = append(fixed, ring[idx:idx+stride]...)
+		}
+	}
+
+	return fixed, true
+}
+
+// ringArea returns the signed area of a closed ring using the shoelace
+// formula. Counter-clockwise rings have a positive area.
+func ringArea(ring []float64, stride int) float64 {
+	area := 0.0
+	for idx := stride; idx < len(ring); idx += stride {
+		area += ring[idx-stride]*ring[idx+1] - ring[idx]*ring[idx-stride+1]
+	}
+
+	return area / 2
+}
+
+// sameXY checks if the coordinates starting at a and b have the same x and y
+func sameXY(a, b []float64) bool {
+	return a[0] == b[0] && a[1] == b[1]
+}
@@ -0,0 +1,105 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"errors"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Repairing", func() {
+	It("closes open rings and collapses duplicate coordinates in the input", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {1, 0}, {1, 0}, {1, 1}, {0, 1}},
+		})
+
+		_, err := antimeridian.Cut(polygon)
+		Expect(errors.Is(err, antimeridian.ErrRingNotClosed)).To(BeTrue())
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithRepair(0))
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{0, 0, 1, 0, 1, 1, 0, 1, 0, 0}))
+	})
+
+	It("collapses duplicate coordinates along the antimeridian", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 0}, {180, 0}, {-170, 0}, {-170, 10}, {180, 10}, {180, 20}, {170, 20}, {170, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithRepair(0))
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		for idx := range multiPolygon.NumPolygons() {
+			coords := multiPolygon.Polygon(idx).LinearRing(0).Coords()
+			for ii := 1; ii < len(coords); ii++ {
+				Expect(coords[ii]).NotTo(Equal(coords[ii-1]))
+			}
+		}
+	})
+
+	It("drops pieces with an area below the threshold", func() {
+		obj := readInput("extra-crossing")
+
+		result, err := antimeridian.Cut(obj)
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(2))
+
+		// a single remaining piece is returned as a polygon
+		result, err = antimeridian.CutWithOptions(obj, antimeridian.WithRepair(3))
+		Expect(err).To(BeNil())
+		Expect(result).To(BeAssignableToTypeOf(&geom.Polygon{}))
+	})
+
+	It("measures the threshold in the units of the coordinate system", func() {
+		// the hole is 1000 m by 1000 m
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{19e6, 0}, {-19e6, 0}, {-19e6, 1e6}, {19e6, 1e6}, {19e6, 0}},
+			{{19.5e6, 1e5}, {19.5e6, 1.01e5}, {19.501e6, 1.01e5}, {19.501e6, 1e5}, {19.5e6, 1e5}},
+		})
+		mercator := antimeridian.WithCoordinateSystem(antimeridian.WebMercator)
+
+		holes := func(minArea float64) int {
+			result, err := antimeridian.CutWithOptions(polygon, mercator, antimeridian.WithRepair(minArea))
+			Expect(err).To(BeNil())
+
+			count := 0
+			for _, piece := range polygonsOf(result) {
+				count += piece.NumLinearRings() - 1
+			}
+
+			return count
+		}
+
+		Expect(holes(5e5)).To(Equal(1))
+		Expect(holes(2e6)).To(Equal(0))
+	})
+
+	It("drops interior rings left too short to be a ring", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+			{{1, 1}, {1, 1}, {2, 2}, {1, 1}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithRepair(0))
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.Polygon).NumLinearRings()).To(Equal(1))
+	})
+})
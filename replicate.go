@@ -0,0 +1,171 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// seamSnapTolerance is how close to the seam, in degrees of the working frame,
+// a longitude of cut output has to be to be taken as on it
+const seamSnapTolerance = 1e-9
+
+// Replicate cuts obj, like CutWithOptions, and returns the pieces along with
+// copies of them shifted east and west by whole turns of the globe, copies on
+// each side, for maps that show more than one width of the world. The result
+// is always a *geom.MultiPolygon, with its polygons ordered from west to east
+// and then from south to north, as with WithSortedPieces. If copies is less
+// than one only the cut pieces are returned.
+//
+// With WithSeamMerge, pieces that meet across the antimeridian are merged so
+// that shapes crossing it are continuous.
+func Replicate(obj geom.T, copies int, opts ...Option) (geom.T, error) {
+	return newCutter(context.Background(), newOptions(opts...)).replicate(obj, max(copies, 0))
+}
+
+func (c *cutter) replicate(obj geom.T, copies int) (geom.T, error) {
+	result, err := c.cut(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		layout     geom.Layout
+		flatCoords []float64
+		endss      [][]int
+	)
+	switch geometry := result.(type) {
+	case *geom.Polygon:
+		layout, flatCoords, endss = geometry.Layout(), slices.Clone(geometry.FlatCoords()), [][]int{geometry.Ends()}
+	case *geom.MultiPolygon:
+		layout, flatCoords, endss = geometry.Layout(), slices.Clone(geometry.FlatCoords()), geometry.Endss()
+	default:
+		return nil, ErrUnsupportedType
+	}
+	stride := layout.Stride()
+
+	// the cut output is put back into the working frame, where the seam is at
+	// ±180 and turns of the globe are whole numbers
+	output := c.frame
+	output.swap = output.restore
+	output.toWork(flatCoords, stride)
+	for idx := 0; idx < len(flatCoords); idx += stride {
+		if math.Abs(math.Abs(flatCoords[idx])-180) < seamSnapTolerance {
+			flatCoords[idx] = math.Copysign(180, flatCoords[idx])
+		}
+	}
+
+	pieces := make([][][]float64, 0, len(endss))
+	offset := 0
+	for _, ends := range endss {
+		pieces = append(pieces, splitRings(flatCoords, offset, ends))
+		if len(ends) > 0 {
+			offset = ends[len(ends)-1]
+		}
+	}
+
+	polygons := make([][][]float64, 0, (2*copies+1)*len(pieces))
+	seamRings := make([][]float64, 0)
+	seamWindings := make([]winding, 0)
+	for turn := -copies; turn <= copies; turn++ {
+		for _, piece := range pieces {
+			shifted := shiftPolygon(piece, stride, float64(360*turn))
+			if c.opts.mergeSeam && onSeam(piece, stride) {
+				w := c.windingOf(layout, piece)
+				for range shifted {
+					seamWindings = append(seamWindings, w)
+				}
+				seamRings = append(seamRings, shifted...)
+				continue
+			}
+
+			polygons = append(polygons, shifted)
+		}
+	}
+
+	if len(seamRings) > 0 {
+		// the pieces do not overlap, so the edges they share along the seam
+		// cancel out
+		for _, merged := range planarPolygons(seamRings, stride) {
+			// planarPolygons winds like RFC 7946, the merged polygons have to
+			// be wound like the pieces they were made from
+			c.orient(layout, [][][]float64{merged}, seamWinding(merged, seamRings, seamWindings, stride))
+			polygons = append(polygons, merged)
+		}
+	}
+
+	c.rotateRings(polygons, stride)
+	slices.SortStableFunc(polygons, func(a, b [][]float64) int {
+		return comparePolygons(a, b, stride)
+	})
+
+	flatCoords, endss = flattenPolygons(polygons)
+	output.fromWork(flatCoords, stride)
+
+	return geom.NewMultiPolygonFlat(layout, flatCoords, endss), nil
+}
+
+// seamWinding returns the winding of the ring of rings that shares a vertex
+// with the exterior of a merged polygon, which is that of the piece the
+// polygon was made from
+func seamWinding(merged [][]float64, rings [][]float64, windings []winding, stride int) winding {
+	exterior := merged[0]
+	for idx := 0; idx < len(exterior); idx += stride {
+		for ringIdx, ring := range rings {
+			for jdx := 0; jdx < len(ring); jdx += stride {
+				if sameXY(exterior[idx:], ring[jdx:]) {
+					return windings[ringIdx]
+				}
+			}
+		}
+	}
+
+	return windings[0]
+}
+
+// shiftPolygon returns a copy of polygon moved east by shift degrees
+func shiftPolygon(polygon [][]float64, stride int, shift float64) [][]float64 {
+	shifted := make([][]float64, len(polygon))
+	for ringIdx, ring := range polygon {
+		shifted[ringIdx] = slices.Clone(ring)
+		for idx := 0; idx < len(ring); idx += stride {
+			shifted[ringIdx][idx] += shift
+		}
+	}
+
+	return shifted
+}
+
+// onSeam reports if the exterior of polygon has an edge along the seam
+func onSeam(polygon [][]float64, stride int) bool {
+	if len(polygon) == 0 {
+		return false
+	}
+
+	ring := polygon[0]
+	for idx := stride; idx < len(ring); idx += stride {
+		x1, y1, x2, y2 := ring[idx-stride], ring[idx-stride+1], ring[idx], ring[idx+1]
+		if math.Abs(x1) == 180 && x1 == x2 && y1 != y2 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/xy"
+)
+
+var _ = Describe("Replicate", func() {
+	crossing := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{170, 0}, {-170, 0}, {-170, 10}, {170, 10}, {170, 0}},
+	})
+
+	It("copies geometries by whole turns of the globe", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{10, 0}, {20, 0}, {20, 10}, {10, 10}, {10, 0}},
+		})
+
+		result, err := antimeridian.Replicate(polygon, 2)
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(5))
+		for idx := range multiPolygon.NumPolygons() {
+			shift := float64(360 * (idx - 2))
+			Expect(multiPolygon.Polygon(idx).LinearRing(0).Coord(0)).To(Equal(geom.Coord{10 + shift, 0}))
+		}
+	})
+
+	It("cuts geometries before copying them", func() {
+		result, err := antimeridian.Replicate(crossing, 1)
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(6))
+		Expect(result.Bounds().Min(0)).To(Equal(-540.0))
+		Expect(result.Bounds().Max(0)).To(Equal(540.0))
+	})
+
+	It("returns only the cut geometry without copies", func() {
+		expected, err := antimeridian.Cut(crossing)
+		Expect(err).To(BeNil())
+
+		result, err := antimeridian.Replicate(crossing, 0)
+		Expect(err).To(BeNil())
+		Expect(result.(*geom.MultiPolygon).NumPolygons()).To(Equal(2))
+		Expect(result.Bounds()).To(Equal(expected.Bounds()))
+	})
+
+	It("merges pieces across the antimeridian", func() {
+		result, err := antimeridian.Replicate(crossing, 1, antimeridian.WithSeamMerge())
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(4))
+
+		expected := [][2]float64{{-540, -530}, {-190, -170}, {170, 190}, {530, 540}}
+		for idx := range multiPolygon.NumPolygons() {
+			polygon := multiPolygon.Polygon(idx)
+			Expect(polygon.NumLinearRings()).To(Equal(1))
+			Expect(xy.IsRingCounterClockwise(geom.XY, polygon.LinearRing(0).FlatCoords())).To(BeTrue())
+			Expect(polygon.Bounds().Min(0)).To(Equal(expected[idx][0]))
+			Expect(polygon.Bounds().Max(0)).To(Equal(expected[idx][1]))
+			Expect(polygon.Area()).To(Equal(10 * (expected[idx][1] - expected[idx][0])))
+		}
+	})
+
+	It("merges polar caps into a band", func() {
+		result, err := antimeridian.Replicate(readInput("north-pole"), 1, antimeridian.WithSeamMerge())
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(1))
+		Expect(result.Bounds().Min(0)).To(Equal(-540.0))
+		Expect(result.Bounds().Max(0)).To(Equal(540.0))
+		Expect(result.Bounds().Max(1)).To(Equal(90.0))
+	})
+
+	It("copies by the width of the coordinate system", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{19e6, 0}, {-19e6, 0}, {-19e6, 1e6}, {19e6, 1e6}, {19e6, 0}},
+		})
+
+		result, err := antimeridian.Replicate(polygon, 1,
+			antimeridian.WithCoordinateSystem(antimeridian.WebMercator),
+			antimeridian.WithSeamMerge())
+		Expect(err).To(BeNil())
+
+		multiPolygon := result.(*geom.MultiPolygon)
+		Expect(multiPolygon.NumPolygons()).To(Equal(4))
+		Expect(multiPolygon.Polygon(2).Bounds().Min(0)).To(BeNumerically("~", 19e6, 1e-6))
+		Expect(multiPolygon.Polygon(2).Bounds().Max(0)).To(BeNumerically("~", 2*halfWidth-19e6, 1e-6))
+	})
+})
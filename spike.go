@@ -0,0 +1,205 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// Spike is an isolated vertex on the wrong side of the antimeridian, such as
+// a vertex at -179.9 in a ring that otherwise lives around +170 or one that
+// has been shifted by 360 degrees. The edges on both sides of a spike jump
+// across the antimeridian, so cutting treats it as two real crossings and
+// produces an extra piece.
+type Spike struct {
+	// Polygon is the index of the multi-polygon member, or 0 for a polygon
+	Polygon int
+	// Ring is the index of the ring within the polygon
+	Ring int
+	// Vertex is the index of the vertex within the ring of the input. The
+	// last vertex of a closed ring is never reported since it repeats the
+	// first.
+	Vertex int
+	// From is the longitude of the vertex in the input
+	From float64
+	// To is the longitude that puts the vertex on the same side of the
+	// antimeridian as its neighbours
+	To float64
+}
+
+// FindSpikes returns the spikes in a polygon or multi-polygon. A polygon that
+// genuinely has a single vertex across the antimeridian looks just the same as
+// one with a spike, so spikes should only be repaired in data known to suffer
+// from them. The longitude convention of obj is detected with
+// DetectLonConvention.
+func FindSpikes(obj geom.T) []Spike {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		convention := DetectLonConvention(geometry)
+		return findSpikes(nil, 0, splitRings(geometry.FlatCoords(), 0, geometry.Ends()), geometry.Stride(), 0, convention)
+	case *geom.MultiPolygon:
+		convention := DetectLonConvention(geometry)
+		var spikes []Spike
+		offset := 0
+		for idx, ends := range geometry.Endss() {
+			spikes = findSpikes(spikes, idx, splitRings(geometry.FlatCoords(), offset, ends), geometry.Stride(), 0, convention)
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+
+		return spikes
+	default:
+		return nil
+	}
+}
+
+// findSpikes appends the spikes of the polygon at index polygon of a
+// multi-polygon to spikes. Adding shift to the longitudes of rings gives
+// longitudes in the given convention, and the longitudes of the spikes are
+// given without it.
+func findSpikes(spikes []Spike, polygon int, rings [][]float64, stride int, shift float64, convention LonConvention) []Spike {
+	maxLon := convention.wrapsAt()
+	minLon := maxLon - 360
+	for ringIdx, ring := range rings {
+		numVertices := numRingVertices(ring, stride)
+		if numVertices < 3 {
+			continue
+		}
+
+		for idx := range numVertices {
+			lon := ring[idx*stride] + shift
+			prev, next, ok := neighbourLons(ring, stride, numVertices, idx)
+			if !ok || isNotFinite(lon) {
+				continue
+			}
+			prev, next = prev+shift, next+shift
+
+			// a vertex shifted by a multiple of 360 degrees is wrapped back
+			// into the range of the convention
+			fixed := lon
+			if (lon < minLon || lon > maxLon) && math.Abs(lon-prev) > 180 && math.Abs(lon-next) > 180 {
+				fixed = wrapLon(lon, minLon)
+			}
+
+			// one that is still on the other side of the antimeridian from
+			// both of its neighbours is mirrored across it
+			if crossesAntimeridian(fixed, prev) && crossesAntimeridian(fixed, next) {
+				fixed = wrapLon(-toLon180(fixed), minLon)
+			}
+
+			if fixed == lon {
+				continue
+			}
+
+			spikes = append(spikes, Spike{Polygon: polygon, Ring: ringIdx, Vertex: idx, From: lon - shift, To: fixed - shift})
+		}
+	}
+
+	return spikes
+}
+
+// numRingVertices returns the number of distinct vertices of a ring, which
+// does not count the last vertex of a closed ring
+func numRingVertices(ring []float64, stride int) int {
+	numVertices := len(ring) / stride
+	if numVertices > 1 && sameXY(ring, lastCoord(ring, stride)) {
+		numVertices--
+	}
+
+	return numVertices
+}
+
+// neighbourLons returns the longitudes of the nearest vertices before and
+// after the one at idx that are not at the same place. ok is false if every
+// vertex is.
+func neighbourLons(ring []float64, stride, numVertices, idx int) (prev, next float64, ok bool) {
+	vertex := ring[idx*stride:]
+	for step := 1; step < numVertices; step++ {
+		before := ring[((idx-step+numVertices)%numVertices)*stride:]
+		if !sameXY(before, vertex) {
+			prev, ok = before[0], true
+			break
+		}
+	}
+
+	for step := 1; step < numVertices; step++ {
+		after := ring[((idx+step)%numVertices)*stride:]
+		if !sameXY(after, vertex) {
+			return prev, after[0], ok
+		}
+	}
+
+	return 0, 0, false
+}
+
+// crossesAntimeridian checks if the shortest way between longitudes a and b
+// crosses the antimeridian
+func crossesAntimeridian(a, b float64) bool {
+	return math.Abs(toLon180(a)-toLon180(b)) > 180
+}
+
+// toLon180 wraps lon into [-180, 180], leaving ±180 as it is
+func toLon180(lon float64) float64 {
+	return wrapLon(lon, -180)
+}
+
+// wrapLon wraps lon into [minLon, minLon+360], leaving longitudes that are
+// already in range as they are
+func wrapLon(lon, minLon float64) float64 {
+	if lon >= minLon && lon <= minLon+360 {
+		return lon
+	}
+
+	return mod(lon-minLon, 360) + minLon
+}
+
+// repairSpikes moves the spikes of rings back next to their neighbours,
+// calling the report function of the options for each one with the
+// longitudes of the input. Rings that are changed are copied first.
+func (c *cutter) repairSpikes(rings [][]float64, stride int) ([][]float64, bool) {
+	spikes := findSpikes(nil, c.polygon, rings, stride, c.frame.shift, c.frame.input)
+	if len(spikes) == 0 {
+		return rings, false
+	}
+
+	rings = slices.Clone(rings)
+	copied := make([]bool, len(rings))
+	for _, spike := range spikes {
+		ring := rings[spike.Ring]
+		if !copied[spike.Ring] {
+			ring = slices.Clone(ring)
+			rings[spike.Ring] = ring
+			copied[spike.Ring] = true
+		}
+
+		closed := numRingVertices(ring, stride) < len(ring)/stride
+		ring[spike.Vertex*stride] = spike.To
+		if spike.Vertex == 0 && closed {
+			ring[len(ring)-stride] = spike.To
+		}
+
+		if c.opts.reportSpike != nil {
+			spike.From, spike.To = c.frame.inputLon(spike.From), c.frame.inputLon(spike.To)
+			c.opts.reportSpike(spike)
+		}
+	}
+
+	return rings, true
+}
@@ -0,0 +1,120 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = Describe("Spikes", func() {
+	It("finds a vertex on the wrong side of the antimeridian", func() {
+		Expect(antimeridian.FindSpikes(readInput("extra-crossing"))).To(Equal([]antimeridian.Spike{
+			{Polygon: 0, Ring: 0, Vertex: 5, From: 175, To: -175},
+		}))
+	})
+
+	It("finds a vertex shifted by 360 degrees", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-189, 0}, {172, 0}, {172, 10}, {170, 10}, {-189, 0}},
+		})
+
+		Expect(antimeridian.FindSpikes(polygon)).To(Equal([]antimeridian.Spike{
+			{Polygon: 0, Ring: 0, Vertex: 0, From: -189, To: 171},
+		}))
+	})
+
+	It("does not report polygons that cross the antimeridian", func() {
+		Expect(antimeridian.FindSpikes(readInput("split"))).To(BeEmpty())
+		Expect(antimeridian.FindSpikes(readInput("complex-split"))).To(BeEmpty())
+	})
+
+	It("repairs spikes before cutting and reports them", func() {
+		obj := readInput("extra-crossing")
+
+		var spikes []antimeridian.Spike
+		result, err := antimeridian.CutWithOptions(obj, antimeridian.WithSpikeRepair(func(spike antimeridian.Spike) {
+			spikes = append(spikes, spike)
+		}))
+		Expect(err).To(BeNil())
+		Expect(result).To(BeAssignableToTypeOf(&geom.Polygon{}))
+		Expect(spikes).To(Equal(antimeridian.FindSpikes(obj)))
+
+		// the input is left untouched
+		Expect(antimeridian.FindSpikes(obj)).To(HaveLen(1))
+	})
+
+	It("does not report vertices that cross the seam of the convention", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{350, 0}, {10, 5}, {350, 10}, {340, 5}, {350, 0}},
+		})
+		Expect(antimeridian.FindSpikes(polygon)).To(BeEmpty())
+
+		var spikes []antimeridian.Spike
+		lon360 := antimeridian.WithLonConvention(antimeridian.Lon360)
+		result, err := antimeridian.CutWithOptions(polygon, lon360, antimeridian.WithSpikeRepair(func(spike antimeridian.Spike) {
+			spikes = append(spikes, spike)
+		}))
+		Expect(err).To(BeNil())
+		Expect(spikes).To(BeEmpty())
+
+		expected, err := antimeridian.CutWithOptions(polygon, lon360)
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal(expected.FlatCoords()))
+	})
+
+	It("reports spikes in the longitudes of the input", func() {
+		obj := readInput("extra-crossing")
+
+		var spikes []antimeridian.Spike
+		_, err := antimeridian.CutWithOptions(obj,
+			antimeridian.WithOutputLonConvention(antimeridian.Lon360),
+			antimeridian.WithSpikeRepair(func(spike antimeridian.Spike) {
+				spikes = append(spikes, spike)
+			}))
+		Expect(err).To(BeNil())
+		Expect(spikes).To(Equal(antimeridian.FindSpikes(obj)))
+	})
+
+	It("reports the vertex indexes of the input when the input is repaired", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{170, 0}, {175, 0}, {175, 0}, {-175, 5}, {-175, 5}, {175, 10}, {170, 10}, {170, 0}},
+		})
+
+		var spikes []antimeridian.Spike
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithRepair(0), antimeridian.WithSpikeRepair(func(spike antimeridian.Spike) {
+			spikes = append(spikes, spike)
+		}))
+		Expect(err).To(BeNil())
+		Expect(spikes).To(Equal([]antimeridian.Spike{
+			{Polygon: 0, Ring: 0, Vertex: 3, From: -175, To: 175},
+			{Polygon: 0, Ring: 0, Vertex: 4, From: -175, To: 175},
+		}))
+		Expect(result.FlatCoords()).To(Equal([]float64{170, 0, 175, 0, 175, 5, 175, 10, 170, 10, 170, 0}))
+	})
+
+	It("keeps the ring closed when the first vertex is repaired", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{-189, 0}, {172, 0}, {172, 10}, {170, 10}, {-189, 0}},
+		})
+
+		result, err := antimeridian.CutWithOptions(polygon, antimeridian.WithSpikeRepair(nil))
+		Expect(err).To(BeNil())
+		Expect(result.FlatCoords()).To(Equal([]float64{171, 0, 172, 0, 172, 10, 170, 10, 171, 0}))
+	})
+})
@@ -0,0 +1,104 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geom"
+)
+
+// Validate checks that a polygon or multi-polygon can be cut. Every ring must
+// have at least four coordinates and be closed, every coordinate must be
+// finite, every latitude must be within [-90, 90] and no ring may wind around
+// the globe more than once. The first problem found is returned as an *Error
+// wrapping one of ErrRingTooShort, ErrRingNotClosed, ErrInvalidCoordinate,
+// ErrLatitudeOutOfRange or ErrMultipleRevolutions.
+func Validate(obj geom.T) error {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		return validatePolygon(0, splitRings(geometry.FlatCoords(), 0, geometry.Ends()), geometry.Stride())
+	case *geom.MultiPolygon:
+		flatCoords := geometry.FlatCoords()
+		offset := 0
+		for idx, ends := range geometry.Endss() {
+			if err := validatePolygon(idx, splitRings(flatCoords, offset, ends), geometry.Stride()); err != nil {
+				return err
+			}
+
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+
+		return nil
+	default:
+		return ErrUnsupportedType
+	}
+}
+
+// validatePolygon checks the rings of the polygon at index polygon of a
+// multi-polygon
+func validatePolygon(polygon int, rings [][]float64, stride int) error {
+	for ringIdx, ring := range rings {
+		numCoords := len(ring) / stride
+		if numCoords < 4 {
+			return &Error{Polygon: polygon, Ring: ringIdx, Vertex: -1, Err: ErrRingTooShort}
+		}
+
+		for idx := range numCoords {
+			coord := coordAt(ring, idx, stride)
+			if slices.ContainsFunc(coord, isNotFinite) {
+				return &Error{Polygon: polygon, Ring: ringIdx, Vertex: idx, Err: ErrInvalidCoordinate}
+			}
+
+			if coord[1] < -90 || coord[1] > 90 {
+				return &Error{Polygon: polygon, Ring: ringIdx, Vertex: idx, Err: ErrLatitudeOutOfRange}
+			}
+		}
+
+		if !slices.Equal(coordAt(ring, 0, stride), lastCoord(ring, stride)) {
+			return &Error{Polygon: polygon, Ring: ringIdx, Vertex: numCoords - 1, Err: ErrRingNotClosed}
+		}
+
+		if turns := revolutions(ring, stride); turns < -1 || turns > 1 {
+			reason := fmt.Sprintf("%d revolutions", turns)
+			return &Error{Polygon: polygon, Ring: ringIdx, Vertex: -1, Reason: reason, Err: ErrMultipleRevolutions}
+		}
+	}
+
+	return nil
+}
+
+// revolutions returns the number of times a closed ring winds around the
+// globe from west to east, taking each edge the shortest way around. Rings
+// around a pole make one revolution, one way or the other, and all other
+// valid rings make none.
+func revolutions(ring []float64, stride int) int {
+	total := 0.0
+	for idx := stride; idx < len(ring); idx += stride {
+		delta := ring[idx] - ring[idx-stride]
+		total += delta - 360*math.Round(delta/360)
+	}
+
+	return int(math.Round(total / 360))
+}
+
+func isNotFinite(val float64) bool {
+	return math.IsNaN(val) || math.IsInf(val, 0)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"errors"
+	"math"
+
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = DescribeTable("Validating polygons",
+	func(coords [][]geom.Coord, expected error, ring, vertex int) {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords(coords)
+
+		err := antimeridian.Validate(polygon)
+		if expected == nil {
+			Expect(err).To(BeNil())
+			return
+		}
+
+		Expect(errors.Is(err, expected)).To(BeTrue(), err.Error())
+
+		var cutErr *antimeridian.Error
+		Expect(errors.As(err, &cutErr)).To(BeTrue())
+		Expect(cutErr.Ring).To(Equal(ring))
+		Expect(cutErr.Vertex).To(Equal(vertex))
+
+		_, err = antimeridian.Cut(polygon)
+		Expect(errors.Is(err, expected)).To(BeTrue())
+	},
+	Entry("valid", [][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, nil, 0, 0),
+	Entry("too short", [][]geom.Coord{{{0, 0}, {1, 0}, {0, 0}}}, antimeridian.ErrRingTooShort, 0, -1),
+	Entry("not closed", [][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}, antimeridian.ErrRingNotClosed, 0, 3),
+	Entry("NaN", [][]geom.Coord{{{0, 0}, {math.NaN(), 0}, {1, 1}, {0, 0}}}, antimeridian.ErrInvalidCoordinate, 0, 1),
+	Entry("infinite", [][]geom.Coord{{{0, 0}, {1, 0}, {1, math.Inf(1)}, {0, 0}}}, antimeridian.ErrInvalidCoordinate, 0, 2),
+	Entry("latitude out of range", [][]geom.Coord{{{0, 0}, {1, 0}, {1, 91}, {0, 0}}}, antimeridian.ErrLatitudeOutOfRange, 0, 2),
+	Entry("two revolutions", [][]geom.Coord{
+		{{0, 0}, {120, 1}, {-120, 2}, {0, 3}, {120, 4}, {-120, 5}, {0, 6}, {0, 0}},
+	}, antimeridian.ErrMultipleRevolutions, 0, -1),
+	Entry("one revolution", [][]geom.Coord{
+		{{0, 80}, {120, 80}, {-120, 80}, {0, 80}},
+	}, nil, 0, 0),
+	Entry("invalid hole", [][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}},
+	}, antimeridian.ErrRingNotClosed, 1, 3),
+)
+
+var _ = Describe("Validation", func() {
+	It("can be turned off", func() {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 95}, {0, 0}}})
+
+		_, err := antimeridian.CutWithOptions(polygon, antimeridian.WithValidation(false))
+		Expect(err).To(BeNil())
+	})
+
+	It("locates the invalid member of a multi-polygon", func() {
+		multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+			{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}},
+		})
+
+		err := antimeridian.Validate(multiPolygon)
+
+		var cutErr *antimeridian.Error
+		Expect(errors.As(err, &cutErr)).To(BeTrue())
+		Expect(cutErr.Polygon).To(Equal(1))
+		Expect(cutErr.Err).To(Equal(antimeridian.ErrRingNotClosed))
+	})
+})
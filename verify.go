@@ -0,0 +1,285 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/twpayne/go-geom"
+)
+
+// ViolationKind identifies the rule of RFC 7946 that a geometry breaks
+type ViolationKind int
+
+const (
+	// ViolationRingTooShort is a ring with fewer than four coordinates
+	ViolationRingTooShort ViolationKind = iota + 1
+	// ViolationRingNotClosed is a ring whose first and last coordinates differ
+	ViolationRingNotClosed
+	// ViolationCrossesAntimeridian is an edge that crosses the antimeridian,
+	// either by jumping more than 180 degrees of longitude or by having a
+	// longitude outside of [-180, 180]
+	ViolationCrossesAntimeridian
+	// ViolationWinding is an exterior ring that is not counter-clockwise or an
+	// interior ring that is not clockwise
+	ViolationWinding
+	// ViolationHoleOutsideShell is an interior ring with a vertex outside of
+	// the exterior ring
+	ViolationHoleOutsideShell
+	// ViolationSelfIntersection is an edge that crosses or overlaps another
+	// edge of the same polygon
+	ViolationSelfIntersection
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case ViolationRingTooShort:
+		return "ring too short"
+	case ViolationRingNotClosed:
+		return "ring not closed"
+	case ViolationCrossesAntimeridian:
+		return "crosses antimeridian"
+	case ViolationWinding:
+		return "wrong winding"
+	case ViolationHoleOutsideShell:
+		return "hole outside shell"
+	case ViolationSelfIntersection:
+		return "self-intersection"
+	default:
+		return fmt.Sprintf("ViolationKind(%d)", int(k))
+	}
+}
+
+// Violation describes a place where a geometry is not compliant with RFC 7946
+type Violation struct {
+	Kind ViolationKind
+	// Polygon is the index of the multi-polygon member, or 0 for a polygon
+	Polygon int
+	// Ring is the index of the ring within the polygon
+	Ring int
+	// Vertex is the index of the vertex within the ring, or -1 if the
+	// violation relates to the whole ring. For violations relating to an edge
+	// it is the vertex the edge starts at.
+	Vertex int
+	// OtherRing and OtherVertex locate the edge that a self-intersecting edge
+	// intersects. They are -1 for other kinds of violation.
+	OtherRing, OtherVertex int
+}
+
+func (v Violation) String() string {
+	var msg strings.Builder
+
+	fmt.Fprintf(&msg, "polygon %d, ring %d", v.Polygon, v.Ring)
+	if v.Vertex >= 0 {
+		fmt.Fprintf(&msg, ", vertex %d", v.Vertex)
+	}
+
+	fmt.Fprintf(&msg, ": %s", v.Kind)
+	if v.OtherRing >= 0 {
+		fmt.Fprintf(&msg, " with ring %d, vertex %d", v.OtherRing, v.OtherVertex)
+	}
+
+	return msg.String()
+}
+
+// Verify checks that a polygon or multi-polygon, such as the output of Cut, is
+// compliant with RFC 7946. No edge may cross the antimeridian, every ring must
+// be closed, exterior rings must be counter-clockwise and interior rings
+// clockwise, interior rings must lie inside their exterior ring and no two
+// edges of a polygon may cross or overlap. Rings are allowed to touch at a
+// point.
+//
+// Every violation found is returned, nil means the geometry is compliant. Other
+// geometry types have nothing to check and always give nil.
+func Verify(obj geom.T) []Violation {
+	switch geometry := obj.(type) {
+	case *geom.Polygon:
+		return verifyPolygon(nil, 0, splitRings(geometry.FlatCoords(), 0, geometry.Ends()), geometry.Stride())
+	case *geom.MultiPolygon:
+		var violations []Violation
+		offset := 0
+		for idx, ends := range geometry.Endss() {
+			violations = verifyPolygon(violations, idx, splitRings(geometry.FlatCoords(), offset, ends), geometry.Stride())
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+
+		return violations
+	default:
+		return nil
+	}
+}
+
+// verifyPolygon appends the violations of the polygon at index polygon of a
+// multi-polygon to violations
+func verifyPolygon(violations []Violation, polygon int, rings [][]float64, stride int) []Violation {
+	report := func(kind ViolationKind, ring, vertex int) {
+		violations = append(violations, Violation{
+			Kind:        kind,
+			Polygon:     polygon,
+			Ring:        ring,
+			Vertex:      vertex,
+			OtherRing:   -1,
+			OtherVertex: -1,
+		})
+	}
+
+	// rings that are too short or not closed can't be checked any further
+	checked := make([][]float64, len(rings))
+	for ringIdx, ring := range rings {
+		numCoords := len(ring) / stride
+		if numCoords < 4 {
+			report(ViolationRingTooShort, ringIdx, -1)
+			continue
+		}
+
+		if !slices.Equal(coordAt(ring, 0, stride), lastCoord(ring, stride)) {
+			report(ViolationRingNotClosed, ringIdx, numCoords-1)
+			continue
+		}
+
+		checked[ringIdx] = ring
+	}
+
+	for ringIdx, ring := range checked {
+		if ring == nil {
+			continue
+		}
+
+		for idx := 0; idx < len(ring); idx += stride {
+			outside := math.Abs(ring[idx]) > 180
+			// edges between two points on the antimeridian run along it
+			// rather than crossing it, and edges along a pole do not move
+			jumps := idx > 0 && math.Abs(ring[idx]-ring[idx-stride]) > 180 &&
+				(math.Abs(ring[idx]) != 180 || math.Abs(ring[idx-stride]) != 180) &&
+				(math.Abs(ring[idx+1]) != 90 || ring[idx+1] != ring[idx-stride+1])
+			if outside || jumps {
+				report(ViolationCrossesAntimeridian, ringIdx, max(0, idx/stride-1))
+			}
+		}
+
+		area := ringArea(ring, stride)
+		if (ringIdx == 0 && area < 0) || (ringIdx > 0 && area > 0) {
+			report(ViolationWinding, ringIdx, -1)
+		}
+	}
+
+	if exterior := checked[0]; exterior != nil {
+		index := newRingIndex(exterior, stride)
+		for ringIdx, ring := range checked[1:] {
+			for idx := 0; idx < len(ring); idx += stride {
+				pt := geom.Coord(ring[idx : idx+2])
+				if !index.containsPoint(pt) && !onRing(pt, exterior, stride) {
+					report(ViolationHoleOutsideShell, ringIdx+1, idx/stride)
+					break
+				}
+			}
+		}
+	}
+
+	edges := make([]*rawEdge, 0)
+	for ringIdx, ring := range checked {
+		for idx := stride; idx < len(ring); idx += stride {
+			a, b := ring[idx-stride:idx], ring[idx:idx+stride]
+			if !sameXY(a, b) {
+				edges = append(edges, newRawEdge(a, b, ringIdx, idx/stride-1))
+			}
+		}
+	}
+
+	intersections := make([]Violation, 0)
+	sweepEdges(edges, func(e1, e2 *rawEdge) {
+		if edgesCross(e1, e2) {
+			// report against the edge that comes first in the polygon
+			if e2.ring < e1.ring || (e2.ring == e1.ring && e2.vertex < e1.vertex) {
+				e1, e2 = e2, e1
+			}
+
+			intersections = append(intersections, Violation{
+				Kind:        ViolationSelfIntersection,
+				Polygon:     polygon,
+				Ring:        e1.ring,
+				Vertex:      e1.vertex,
+				OtherRing:   e2.ring,
+				OtherVertex: e2.vertex,
+			})
+		}
+	})
+
+	// the sweep visits edges from west to east, put them back in ring order
+	slices.SortFunc(intersections, func(a, b Violation) int {
+		return cmpInts(a.Ring, b.Ring, a.Vertex, b.Vertex, a.OtherRing, b.OtherRing, a.OtherVertex, b.OtherVertex)
+	})
+
+	return append(violations, intersections...)
+}
+
+// edgesCross checks if e1 and e2 cross each other or overlap along a
+// stretch. Edges that only touch, such as neighbouring edges of a ring, do not
+// cross.
+func edgesCross(e1, e2 *rawEdge) bool {
+	rx, ry := e1.b[0]-e1.a[0], e1.b[1]-e1.a[1]
+	sx, sy := e2.b[0]-e2.a[0], e2.b[1]-e2.a[1]
+	qx, qy := e2.a[0]-e1.a[0], e2.a[1]-e1.a[1]
+	denom := rx*sy - ry*sx
+
+	if denom == 0 {
+		if qx*ry-qy*rx != 0 {
+			// parallel
+			return false
+		}
+
+		// collinear, check whether the projection of e2 onto e1 overlaps e1
+		t1, t2 := project(e1, e2.a), project(e1, e2.b)
+		return min(max(t1, t2), 1)-max(min(t1, t2), 0) > intersectionTolerance
+	}
+
+	t := (qx*sy - qy*sx) / denom
+	u := (qx*ry - qy*rx) / denom
+	return t > intersectionTolerance && t < 1-intersectionTolerance &&
+		u > intersectionTolerance && u < 1-intersectionTolerance
+}
+
+// onRing checks if pt lies on one of the edges of ring
+func onRing(pt geom.Coord, ring []float64, stride int) bool {
+	for idx := stride; idx < len(ring); idx += stride {
+		a, b := ring[idx-stride:idx], ring[idx:idx+stride]
+		cross := (b[0]-a[0])*(pt[1]-a[1]) - (b[1]-a[1])*(pt[0]-a[0])
+		if cross == 0 &&
+			min(a[0], b[0]) <= pt[0] && pt[0] <= max(a[0], b[0]) &&
+			min(a[1], b[1]) <= pt[1] && pt[1] <= max(a[1], b[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cmpInts compares pairs of values in turn, returning the result of the first
+// pair that differs
+func cmpInts(pairs ...int) int {
+	for idx := 0; idx+1 < len(pairs); idx += 2 {
+		if pairs[idx] != pairs[idx+1] {
+			return pairs[idx] - pairs[idx+1]
+		}
+	}
+
+	return 0
+}
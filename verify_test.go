@@ -0,0 +1,114 @@
+// Copyright 2024
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antimeridian_test
+
+import (
+	"github.com/go-geospatial/antimeridian"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/twpayne/go-geom"
+)
+
+var _ = DescribeTable("Verifying polygons",
+	func(coords [][]geom.Coord, expected []antimeridian.Violation) {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords(coords)
+		Expect(antimeridian.Verify(polygon)).To(Equal(expected))
+	},
+	Entry("compliant", [][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}},
+	}, nil),
+	Entry("along a pole", [][]geom.Coord{
+		{{-180, 80}, {180, 80}, {180, 90}, {-180, 90}, {-180, 80}},
+	}, nil),
+	Entry("too short", [][]geom.Coord{{{0, 0}, {1, 0}, {0, 0}}}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationRingTooShort, Ring: 0, Vertex: -1, OtherRing: -1, OtherVertex: -1},
+	}),
+	Entry("not closed", [][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationRingNotClosed, Ring: 0, Vertex: 3, OtherRing: -1, OtherVertex: -1},
+	}),
+	Entry("crosses the antimeridian", [][]geom.Coord{
+		{{170, 0}, {-170, 0}, {-170, 10}, {170, 10}, {170, 0}},
+	}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationCrossesAntimeridian, Ring: 0, Vertex: 0, OtherRing: -1, OtherVertex: -1},
+		{Kind: antimeridian.ViolationCrossesAntimeridian, Ring: 0, Vertex: 2, OtherRing: -1, OtherVertex: -1},
+		{Kind: antimeridian.ViolationWinding, Ring: 0, Vertex: -1, OtherRing: -1, OtherVertex: -1},
+	}),
+	Entry("longitude beyond 180", [][]geom.Coord{
+		{{170, 0}, {190, 0}, {190, 10}, {170, 10}, {170, 0}},
+	}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationCrossesAntimeridian, Ring: 0, Vertex: 0, OtherRing: -1, OtherVertex: -1},
+		{Kind: antimeridian.ViolationCrossesAntimeridian, Ring: 0, Vertex: 1, OtherRing: -1, OtherVertex: -1},
+	}),
+	Entry("wrong winding", [][]geom.Coord{
+		{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}},
+	}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationWinding, Ring: 0, Vertex: -1, OtherRing: -1, OtherVertex: -1},
+		{Kind: antimeridian.ViolationWinding, Ring: 1, Vertex: -1, OtherRing: -1, OtherVertex: -1},
+	}),
+	Entry("hole outside shell", [][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{20, 2}, {20, 8}, {28, 8}, {28, 2}, {20, 2}},
+	}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationHoleOutsideShell, Ring: 1, Vertex: 0, OtherRing: -1, OtherVertex: -1},
+	}),
+	Entry("hole touching shell", [][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{0, 5}, {5, 8}, {5, 2}, {0, 5}},
+	}, nil),
+	Entry("bowtie", [][]geom.Coord{
+		{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0}},
+	}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationSelfIntersection, Ring: 0, Vertex: 0, OtherRing: 0, OtherVertex: 2},
+	}),
+	Entry("hole crossing shell", [][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{5, 5}, {5, 15}, {8, 15}, {8, 5}, {5, 5}},
+	}, []antimeridian.Violation{
+		{Kind: antimeridian.ViolationHoleOutsideShell, Ring: 1, Vertex: 1, OtherRing: -1, OtherVertex: -1},
+		{Kind: antimeridian.ViolationSelfIntersection, Ring: 0, Vertex: 2, OtherRing: 1, OtherVertex: 0},
+		{Kind: antimeridian.ViolationSelfIntersection, Ring: 0, Vertex: 2, OtherRing: 1, OtherVertex: 2},
+	}),
+)
+
+var _ = Describe("Verification", func() {
+	It("finds no violations in the output of Cut", func() {
+		for _, name := range []string{
+			"almost-180", "both-poles", "complex-split", "crossing-latitude", "cw-only",
+			"cw-split", "extra-crossing", "latitude-band", "multi-no-antimeridian",
+			"multi-split", "north-pole", "one-ccw-hole", "one-hole", "over-180", "overlap",
+			"point-on-antimeridian", "simple-with-ccw-hole", "simple", "south-pole",
+			"split", "two-holes",
+		} {
+			result, err := antimeridian.Cut(readInput(name))
+			Expect(err).To(BeNil())
+			Expect(antimeridian.Verify(result)).To(BeEmpty(), name)
+		}
+	})
+
+	It("locates violations in multi-polygon members", func() {
+		multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+			{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+			{{{20, 0}, {20, 10}, {30, 10}, {30, 0}, {20, 0}}},
+		})
+
+		violations := antimeridian.Verify(multiPolygon)
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Polygon).To(Equal(1))
+		Expect(violations[0].String()).To(Equal("polygon 1, ring 0: wrong winding"))
+	})
+})